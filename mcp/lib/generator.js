@@ -453,7 +453,8 @@ function generateDockerCompose(projectRoot, team, registry, tokens) {
   // Note: paths are relative to the compose file location (generated/)
   // so ../ reaches the project root.
 
-  // Meeting Board
+  // Meeting Board (reads tickets with the PO's planning board token)
+  const po = agents.find((a) => a.role === 'po');
   services['meeting-board'] = {
     build: { context: '../meeting-board', dockerfile: 'Dockerfile' },
     container_name: 'devteam-meeting-board',
@@ -465,6 +466,13 @@ function generateDockerCompose(projectRoot, team, registry, tokens) {
       'DB_NAME=${MONGO_DB:-meetingboard}',
       'PORT=8080',
       'AGENTS_REGISTRY=/data/agents-registry.json',
+      'PLANNING_BOARD_URL=http://project-board:3000',
+      `PLANNING_BOARD_TOKEN=${po ? `\${TOKEN_${po.name.toUpperCase()}}` : ''}`,
+      'TICKET_THREADS=${TICKET_THREADS:-false}',
+      'HUMAN_COMMS_TYPE=${HUMAN_COMMS_TYPE:-meeting-board}',
+      'HUMAN_COMMS_WEBHOOK_URL=${HUMAN_COMMS_WEBHOOK_URL:-}',
+      'HUMAN_COMMS_MENTIONS=${HUMAN_COMMS_MENTIONS:-}',
+      'HUMAN_COMMS_INBOUND_SECRET=${HUMAN_COMMS_INBOUND_SECRET:-}',
     ],
     volumes: ['./agents-registry.json:/data/agents-registry.json:ro'],
     depends_on: { mongo: { condition: 'service_healthy' } },
@@ -0,0 +1,249 @@
+// Package bridge mirrors a meeting board channel, normally #humans, to a
+// Discord or Slack incoming webhook, so human stakeholders see the team's
+// posts where they already are.
+package bridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bridge kinds, as in HUMAN_COMMS_TYPE.
+const (
+	KindDiscord = "discord"
+	KindSlack   = "slack"
+)
+
+const (
+	// attempts is how many times a post is tried before it is dropped.
+	attempts = 3
+
+	// queueSize is how many messages may wait to be mirrored before new
+	// ones are dropped.
+	queueSize = 256
+
+	// quoteLength caps the quoted thread root on a mirrored reply.
+	quoteLength = 80
+)
+
+// maxContent is the longest message each kind accepts, in characters.
+var maxContent = map[string]int{
+	KindDiscord: 2000,
+	KindSlack:   3000,
+}
+
+// mentionPattern matches @handles in message text.
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_.-]+)`)
+
+// Bridge mirrors new top-level messages and replies in one channel to an
+// incoming webhook. Mentions are rewritten with Mentions where a board handle
+// has a platform equivalent ("human" -> "<@123456>") and emphasized
+// otherwise. Replies quote the start of their thread's root, since incoming
+// webhooks cannot thread. Messages that came in through the bridge are not
+// sent back.
+type Bridge struct {
+	Kind       string
+	WebhookURL string
+	ChannelID  primitive.ObjectID
+	Mentions   map[string]string // board handle (lower case) -> platform mention
+	Store      *store.Store      // looks up thread roots; may be nil
+	Client     *http.Client      // nil means a client with a ten-second timeout
+
+	queue chan *models.Message
+}
+
+// New creates a Bridge of the given kind; call Run to start it.
+func New(kind, webhookURL string, channelID primitive.ObjectID) (*Bridge, error) {
+	if _, ok := maxContent[kind]; !ok {
+		return nil, fmt.Errorf("bridge: unknown kind %q, use %q or %q", kind, KindDiscord, KindSlack)
+	}
+	if webhookURL == "" {
+		return nil, fmt.Errorf("bridge: %s needs a webhook URL", kind)
+	}
+	return &Bridge{
+		Kind:       kind,
+		WebhookURL: webhookURL,
+		ChannelID:  channelID,
+		queue:      make(chan *models.Message, queueSize),
+	}, nil
+}
+
+// IsBridged reports whether a message came in through a bridge.
+func IsBridged(m *models.Message) bool {
+	return m.Source == KindDiscord || m.Source == KindSlack
+}
+
+// Mirror queues a newly created message for mirroring without blocking.
+// Messages in other channels and bridged messages are ignored.
+func (b *Bridge) Mirror(m *models.Message) {
+	if m.ChannelID != b.ChannelID || IsBridged(m) {
+		return
+	}
+	select {
+	case b.queue <- m:
+	default:
+		log.Printf("bridge: queue full, dropped message %s", m.ID.Hex())
+	}
+}
+
+// Run mirrors queued messages until ctx is cancelled.
+func (b *Bridge) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-b.queue:
+			b.send(ctx, m)
+		}
+	}
+}
+
+// send posts m, retrying with a growing delay.
+func (b *Bridge) send(ctx context.Context, m *models.Message) {
+	body, err := json.Marshal(b.payload(b.render(ctx, m), m))
+	if err != nil {
+		log.Printf("bridge: marshal message %s: %v", m.ID.Hex(), err)
+		return
+	}
+	delay := time.Second
+	for i := 1; ; i++ {
+		err = b.post(ctx, body)
+		if err == nil {
+			return
+		}
+		if i == attempts {
+			log.Printf("bridge: %s: dropped message %s after %d attempts: %v", b.Kind, m.ID.Hex(), i, err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post sends one webhook body to the platform.
+func (b *Bridge) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := b.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	// Discord answers 204, Slack 200 "ok".
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// render returns m's text as the platform shows it: mentions rewritten, a
+// quote of the thread root for replies, and the message's short ref so
+// inbound replies can point back at it.
+func (b *Bridge) render(ctx context.Context, m *models.Message) string {
+	var lines []string
+	if m.ThreadID != nil && b.Store != nil {
+		if root, err := b.Store.GetMessageByID(ctx, *m.ThreadID); err == nil {
+			lines = append(lines, b.quote(fmt.Sprintf("Re: %s: %s", speaker(root), firstLine(root.Content, quoteLength))))
+		}
+	}
+	lines = append(lines, b.text(m.Content))
+	if m.Seq > 0 {
+		lines = append(lines, b.small(fmt.Sprintf("#%d", m.Seq)))
+	}
+
+	text := strings.Join(lines, "\n")
+	if limit := maxContent[b.Kind]; utf8.RuneCountInString(text) > limit {
+		text = string([]rune(text)[:limit-1]) + "…"
+	}
+	return text
+}
+
+// text escapes content for the platform and rewrites its mentions.
+func (b *Bridge) text(content string) string {
+	if b.Kind == KindSlack {
+		content = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(content)
+	}
+	return mentionPattern.ReplaceAllStringFunc(content, func(s string) string {
+		if mention, ok := b.Mentions[strings.ToLower(s[1:])]; ok {
+			return mention
+		}
+		if b.Kind == KindSlack {
+			return "*" + s + "*"
+		}
+		return "**" + s + "**"
+	})
+}
+
+// quote renders s as a block quote.
+func (b *Bridge) quote(s string) string {
+	return "> " + b.text(s)
+}
+
+// small renders s as fine print.
+func (b *Bridge) small(s string) string {
+	if b.Kind == KindSlack {
+		return "_" + s + "_"
+	}
+	return "-# " + s
+}
+
+// payload builds the webhook body for text written by m's author.
+func (b *Bridge) payload(text string, m *models.Message) any {
+	if b.Kind == KindSlack {
+		return map[string]any{"text": "*" + speaker(m) + "*: " + text}
+	}
+	return map[string]any{
+		"username": speaker(m),
+		"content":  text,
+		// Only mapped user and role mentions ping; never @everyone.
+		"allowed_mentions": map[string]any{"parse": []string{"users", "roles"}},
+	}
+}
+
+// speaker names a message's author as "Name (role)", or by ID.
+func speaker(m *models.Message) string {
+	name := m.AuthorName
+	if name == "" {
+		name = m.Author
+	}
+	if m.AuthorRole != "" && !strings.EqualFold(m.AuthorRole, name) {
+		return name + " (" + m.AuthorRole + ")"
+	}
+	return name
+}
+
+// firstLine returns the first line of s, clipped to limit characters.
+func firstLine(s string, limit int) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	if utf8.RuneCountInString(s) > limit {
+		s = strings.TrimSpace(string([]rune(s)[:limit-1])) + "…"
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/bridge"
+	"github.com/devteam/meeting-board/internal/webhooks"
+)
+
+// maxBridgeBody caps the size of an inbound bridge request.
+const maxBridgeBody = 1 << 20
+
+// bridgeTimestampHeader carries the Unix time an inbound bridge request was
+// signed at. Requests signed more than bridgeMaxSkew away from now are
+// rejected, so a captured request cannot be replayed later.
+const (
+	bridgeTimestampHeader = "X-Board-Timestamp"
+	bridgeMaxSkew         = 5 * time.Minute
+)
+
+// BridgeInbound handles POST /bridge/inbound.
+// Relays a human's reply from Discord or Slack into the bridged channel,
+// authored as the manager, so it reaches the team like any other post. The
+// request is authenticated by X-Board-Signature, an HMAC-SHA256 with the
+// bridge secret over X-Board-Timestamp, a "." and the raw body. Requests
+// whose timestamp is more than bridgeMaxSkew from now are rejected.
+//
+// Body: {"text" (or "content"), "reply_to" (message ID or short ref such as
+// "#42", as shown on mirrored posts), "source" ("discord" or "slack",
+// default the bridge's kind)}.
+func (h *Handlers) BridgeInbound(w http.ResponseWriter, r *http.Request) {
+	if h.Bridge == nil || h.BridgeSecret == "" {
+		respondError(w, http.StatusNotFound, "bridge is disabled")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBridgeBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	ts := r.Header.Get(bridgeTimestampHeader)
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "missing or invalid "+bridgeTimestampHeader+" header")
+		return
+	}
+	if skew := time.Since(time.Unix(sec, 0)); skew > bridgeMaxSkew || skew < -bridgeMaxSkew {
+		respondError(w, http.StatusUnauthorized, "request timestamp is too old or too far in the future")
+		return
+	}
+	want := webhooks.Sign(h.BridgeSecret, append([]byte(ts+"."), body...))
+	if !hmac.Equal([]byte(r.Header.Get(webhooks.HeaderSignature)), []byte(want)) {
+		respondError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	var in struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+		ReplyTo string `json:"reply_to"`
+		Source  string `json:"source"`
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	source := strings.ToLower(strings.TrimSpace(in.Source))
+	switch source {
+	case "":
+		source = h.Bridge.Kind
+	case bridge.KindDiscord, bridge.KindSlack:
+	default:
+		respondError(w, http.StatusBadRequest, "source must be \"discord\" or \"slack\"")
+		return
+	}
+
+	ch, err := h.Store.GetChannelByID(r.Context(), h.Bridge.ChannelID)
+	if err != nil {
+		log.Printf("handler: bridge inbound: resolve channel: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve bridged channel")
+		return
+	}
+
+	req := &messageRequest{Content: in.Text, Body: in.Content, ReplyTo: in.ReplyTo, source: source}
+	r = r.WithContext(context.WithValue(r.Context(), authorKey, "manager"))
+	h.createMessage(w, r, ch, req, nil)
+}
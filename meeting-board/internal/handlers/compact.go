@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ---------------------------------------------------------------------------
+// Compact transcripts
+// ---------------------------------------------------------------------------
+
+// Agents feed listings straight into a model context, where ObjectIDs, empty
+// arrays and full timestamps cost tokens without adding meaning. Listing
+// endpoints can instead render a terse transcript, one line per message:
+//
+//	— 2026-10-17 —
+//	[14:02] #41 Juniper(dev) → @cq: Ready for review
+//	[14:05] #42 ↳#41 Quill(cq): Looks good [approve]
+//
+// "#41" is the message's short ref: its per-channel seq, which never changes
+// and is accepted as a reply target. Times are UTC, with a date line
+// whenever the day changes.
+
+// wantsCompact reports whether the caller asked for a compact transcript,
+// with format=compact or an Accept header naming text/plain or
+// text/markdown. format=json always selects JSON.
+func wantsCompact(r *http.Request) bool {
+	switch r.URL.Query().Get("format") {
+	case "compact":
+		return true
+	case "json":
+		return false
+	}
+	accept := r.Header.Get("Accept")
+	if strings.Contains(accept, "application/json") {
+		return false
+	}
+	return strings.Contains(accept, "text/plain") || strings.Contains(accept, "text/markdown")
+}
+
+// shortRef returns the short ref of a message: "#<seq>", or
+// "<channel>#<seq>" when channel is given. Messages from before sequence
+// numbers existed fall back to their ID.
+func shortRef(channel string, seq int64, id primitive.ObjectID) string {
+	if seq <= 0 {
+		return id.Hex()
+	}
+	return channel + "#" + strconv.FormatInt(seq, 10)
+}
+
+// parseShortRef parses a short ref ("#42", "planning#42" or "#planning#42")
+// into its seq and channel name, which is empty if the ref is unqualified.
+// ok is false if ref is not a short ref.
+func parseShortRef(ref string) (seq int64, channel string, ok bool) {
+	i := strings.LastIndexByte(ref, '#')
+	if i < 0 {
+		return 0, "", false
+	}
+	seq, err := strconv.ParseInt(ref[i+1:], 10, 64)
+	if err != nil || seq <= 0 {
+		return 0, "", false
+	}
+	return seq, strings.TrimPrefix(ref[:i], "#"), true
+}
+
+// authorLabel renders an author as "Name(role)", or just the name when the
+// role is unknown or the same.
+func authorLabel(id, name, role string) string {
+	if name == "" {
+		name = id
+	}
+	if role == "" || strings.EqualFold(role, name) {
+		return name
+	}
+	return name + "(" + role + ")"
+}
+
+// reactionSummary renders reaction counts as "[ack×2 approve 👍]": named
+// reactions in vocabulary order, then emoji.
+func reactionSummary(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	var names, emoji []string
+	known := make(map[string]bool, len(models.ReactionNames))
+	for _, name := range models.ReactionNames {
+		known[name] = true
+		if counts[name] > 0 {
+			names = append(names, name)
+		}
+	}
+	for name, n := range counts {
+		if n > 0 && !known[name] {
+			emoji = append(emoji, name)
+		}
+	}
+	sort.Strings(emoji)
+
+	var parts []string
+	for _, name := range append(names, emoji...) {
+		if n := counts[name]; n > 1 {
+			parts = append(parts, fmt.Sprintf("%s×%d", name, n))
+		} else {
+			parts = append(parts, name)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// transcript accumulates a compact rendering.
+type transcript struct {
+	b     strings.Builder
+	day   string
+	roots map[primitive.ObjectID]int64 // seq of thread roots, for "↳#N"
+}
+
+// newTranscript returns an empty transcript able to label replies to the
+// given messages' threads. Thread roots outside msgs are looked up; if that
+// fails, their replies are labelled "↳thread" instead.
+func (h *Handlers) newTranscript(ctx context.Context, msgs []*models.Message) *transcript {
+	t := &transcript{roots: make(map[primitive.ObjectID]int64)}
+	for _, m := range msgs {
+		t.roots[m.ID] = m.Seq
+	}
+	var missing []primitive.ObjectID
+	for _, m := range msgs {
+		if m.ThreadID == nil {
+			continue
+		}
+		if _, ok := t.roots[*m.ThreadID]; !ok {
+			t.roots[*m.ThreadID] = 0
+			missing = append(missing, *m.ThreadID)
+		}
+	}
+	if len(missing) > 0 {
+		seqs, err := h.Store.MessageSeqs(ctx, missing)
+		if err != nil {
+			log.Printf("handler: transcript thread roots: %v", err)
+		}
+		for id, seq := range seqs {
+			t.roots[id] = seq
+		}
+	}
+	return t
+}
+
+// heading starts a new section. The next message repeats the date line.
+func (t *transcript) heading(format string, args ...any) {
+	if t.b.Len() > 0 {
+		t.b.WriteByte('\n')
+	}
+	t.b.WriteString("## ")
+	fmt.Fprintf(&t.b, format, args...)
+	t.b.WriteByte('\n')
+	t.day = ""
+}
+
+// stamp writes a date line if at differs from the previous message's day,
+// and returns the "[15:04]" time prefix.
+func (t *transcript) stamp(at time.Time) string {
+	at = at.UTC()
+	if day := at.Format("2006-01-02"); day != t.day {
+		t.day = day
+		t.b.WriteString("— " + day + " —\n")
+	}
+	return at.Format("[15:04]")
+}
+
+// line writes one transcript entry. Continuation lines of multi-line content
+// are indented so every entry starts with its time.
+func (t *transcript) line(prefix, content, suffix string) {
+	t.b.WriteString(prefix)
+	t.b.WriteString(": ")
+	t.b.WriteString(strings.ReplaceAll(strings.TrimRight(content, "\n"), "\n", "\n  "))
+	if suffix != "" {
+		t.b.WriteByte(' ')
+		t.b.WriteString(suffix)
+	}
+	t.b.WriteByte('\n')
+}
+
+// message writes m as "[15:04] #42 ↳#40 Name(role) → @a @b: content", followed
+// by "(edited)", its reactions and any extra annotations.
+func (t *transcript) message(m *models.Message, extra ...string) {
+	parts := []string{t.stamp(m.CreatedAt), shortRef("", m.Seq, m.ID)}
+	if m.ThreadID != nil {
+		if seq := t.roots[*m.ThreadID]; seq > 0 {
+			parts = append(parts, "↳"+shortRef("", seq, *m.ThreadID))
+		} else {
+			parts = append(parts, "↳thread")
+		}
+	}
+	parts = append(parts, authorLabel(m.Author, m.AuthorName, m.AuthorRole))
+	if len(m.Mentions) > 0 {
+		parts = append(parts, "→ @"+strings.Join(m.Mentions, " @"))
+	}
+
+	content := m.Content
+	var suffix []string
+	if m.Deleted {
+		content = "[deleted]"
+	} else if m.EditedAt != nil {
+		suffix = append(suffix, "(edited)")
+	}
+	if s := reactionSummary(m.ReactionCounts); s != "" {
+		suffix = append(suffix, s)
+	}
+	if s := ticketSummary(m.TicketContext); s != "" {
+		suffix = append(suffix, s)
+	}
+	for _, e := range extra {
+		if e != "" {
+			suffix = append(suffix, e)
+		}
+	}
+	t.line(strings.Join(parts, " "), content, strings.Join(suffix, " "))
+}
+
+// mention writes an inbox item as "[15:04] planning#42 Name(role) → @cq: content".
+func (t *transcript) mention(m *models.Mention) {
+	parts := []string{
+		t.stamp(m.CreatedAt),
+		shortRef(m.Channel, m.Seq, m.MessageID),
+		authorLabel(m.Author, m.AuthorName, m.AuthorRole),
+		"→ @" + m.Recipient,
+	}
+	suffix := ""
+	if m.Responded {
+		suffix = "(responded)"
+	}
+	t.line(strings.Join(parts, " "), m.Content, suffix)
+}
+
+// footer writes the listing's metadata as "-- key=value ...", skipping empty
+// values.
+func (t *transcript) footer(pairs ...any) {
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if v := fmt.Sprint(pairs[i+1]); v != "" {
+			parts = append(parts, fmt.Sprintf("%v=%s", pairs[i], v))
+		}
+	}
+	if len(parts) > 0 {
+		t.b.WriteString("-- " + strings.Join(parts, " ") + "\n")
+	}
+}
+
+// respond writes the transcript as text/markdown if the caller accepts it,
+// text/plain otherwise.
+func (t *transcript) respond(w http.ResponseWriter, r *http.Request) {
+	contentType := "text/plain; charset=utf-8"
+	if strings.Contains(r.Header.Get("Accept"), "text/markdown") {
+		contentType = "text/markdown; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(t.b.String()))
+}
+
+// messagePtrs returns pointers to the elements of msgs.
+func messagePtrs(msgs []models.Message) []*models.Message {
+	ptrs := make([]*models.Message, len(msgs))
+	for i := range msgs {
+		ptrs[i] = &msgs[i]
+	}
+	return ptrs
+}
+
+// respondCompactMessages writes a channel's messages as a transcript, followed
+// by the listing's metadata as footer pairs.
+func (h *Handlers) respondCompactMessages(w http.ResponseWriter, r *http.Request, ch *models.Channel, messages []models.Message, footer ...any) {
+	t := h.newTranscript(r.Context(), messagePtrs(messages))
+	t.heading("#%s", ch.Name)
+	for i := range messages {
+		t.message(&messages[i])
+	}
+	t.footer(footer...)
+	t.respond(w, r)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+)
+
+// canModifyMessage reports whether the caller may edit or delete msg: only
+// its author or the manager.
+func canModifyMessage(r *http.Request, msg *models.Message) bool {
+	author := getAuthor(r)
+	return author == msg.Author || author == "manager"
+}
+
+// EditMessage handles PATCH /api/messages/{id}.
+// Body: {"content": "..."} (or "body"), optionally with "mentions". Mentions
+// are re-parsed from the new content and merged with the explicit list; the
+// previous version is kept in the message's history. Only the author or the
+// manager may edit.
+func (h *Handlers) EditMessage(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	if !canModifyMessage(r, msg) {
+		respondError(w, http.StatusForbidden, "only the author or the manager can edit this message")
+		return
+	}
+	if ch.Archived {
+		respondError(w, http.StatusConflict, "channel is archived: "+ch.Name)
+		return
+	}
+
+	req, warnings, err := decodeMessageRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Channel != "" || req.replyTarget() != "" {
+		warnings = append(warnings, "channel and reply target cannot be changed by an edit; ignored")
+	}
+	content := req.text()
+	if strings.TrimSpace(content) == "" {
+		respondError(w, http.StatusBadRequest, "message content is required (use \"content\" or \"body\" field)")
+		return
+	}
+
+	mentions, mentionWarnings := h.collectMentions(content, req.Mentions)
+	warnings = append(warnings, mentionWarnings...)
+
+	author := getAuthor(r)
+	before, after, err := h.Store.EditMessage(r.Context(), msg.ID, content, mentions, author)
+	if err != nil {
+		if err == store.ErrMessageDeleted {
+			respondError(w, http.StatusConflict, "message has been deleted")
+			return
+		}
+		log.Printf("handler: edit message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to edit message")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "message.edit",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+			"before":       map[string]any{"content": before.Content, "mentions": before.Mentions},
+			"after":        map[string]any{"content": after.Content, "mentions": after.Mentions},
+		},
+	})
+
+	h.addTicketContext(r.Context(), after)
+	h.publish(ch.ID, EventMessageUpdated, after)
+
+	respondJSON(w, http.StatusOK, postMessageResponse{Message: after, Warnings: warnings})
+}
+
+// DeleteMessage handles DELETE /api/messages/{id}.
+// The message becomes a tombstone (deleted: true, empty content) so threads
+// and sequence numbers stay intact; the removed content is kept in its
+// history and in the audit log. A pinned message is unpinned. Only the
+// author or the manager may delete, and not in archived channels.
+func (h *Handlers) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	if !canModifyMessage(r, msg) {
+		respondError(w, http.StatusForbidden, "only the author or the manager can delete this message")
+		return
+	}
+	if ch.Archived {
+		respondError(w, http.StatusConflict, "channel is archived: "+ch.Name)
+		return
+	}
+
+	author := getAuthor(r)
+	before, after, err := h.Store.DeleteMessage(r.Context(), msg.ID, author)
+	if err != nil {
+		if err == store.ErrMessageDeleted {
+			respondError(w, http.StatusConflict, "message has already been deleted")
+			return
+		}
+		log.Printf("handler: delete message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to delete message")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "message.delete",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+			"before":       map[string]any{"content": before.Content, "mentions": before.Mentions},
+			"after":        nil,
+		},
+	})
+
+	h.publish(ch.ID, EventMessageDeleted, after)
+
+	// A deleted message does not keep its pin, or its place under the cap.
+	if removed, err := h.Store.UnpinMessage(r.Context(), ch.ID, msg.ID); err != nil {
+		log.Printf("handler: delete message: unpin: %v", err)
+	} else if removed {
+		h.publish(ch.ID, EventPinRemoved, unpinEvent{MessageID: msg.ID, UnpinnedBy: author})
+	}
+
+	respondJSON(w, http.StatusOK, after)
+}
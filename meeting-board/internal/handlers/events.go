@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Event types broadcast to WebSocket subscribers of a channel. Every event is
+// a JSON object with "type" and "channel_id" alongside the event's own
+// fields; message and reaction events carry the full message, so a plain
+// message listener keeps working for message.created.
+const (
+	EventMessageCreated = "message.created"
+	EventMessageUpdated = "message.updated"
+	EventMessageDeleted = "message.deleted"
+
+	EventReactionAdded   = "reaction.added"
+	EventReactionRemoved = "reaction.removed"
+
+	EventPinAdded   = "pin.added"
+	EventPinRemoved = "pin.removed"
+
+	EventChannelUpdated  = "channel.updated"
+	EventChannelCleared  = "channel.cleared"
+	EventChannelRestored = "channel.restored"
+)
+
+// EventTypes lists every event type, for validating webhook subscriptions.
+var EventTypes = []string{
+	EventMessageCreated, EventMessageUpdated, EventMessageDeleted,
+	EventReactionAdded, EventReactionRemoved,
+	EventPinAdded, EventPinRemoved,
+	EventChannelUpdated, EventChannelCleared, EventChannelRestored,
+}
+
+// publish broadcasts an event to the channel's subscribers, records it for
+// any matching webhooks and, for new messages, queues it for the bridge.
+// payload must marshal to a JSON object; its fields are merged with "type"
+// and "channel_id". All hub broadcasts go through here.
+func (h *Handlers) publish(channelID primitive.ObjectID, eventType string, payload any) {
+	fields := map[string]any{}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("handler: marshal %s event: %v", eventType, err)
+			return
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			log.Printf("handler: %s event payload is not an object: %v", eventType, err)
+			return
+		}
+	}
+	fields["type"] = eventType
+	fields["channel_id"] = channelID.Hex()
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		log.Printf("handler: marshal %s event: %v", eventType, err)
+		return
+	}
+	h.Hub.Broadcast(channelID.Hex(), data)
+	if h.Webhooks != nil {
+		h.Webhooks.Publish(eventType, channelID, fields)
+	}
+	if m, ok := payload.(*models.Message); ok && h.Bridge != nil && eventType == EventMessageCreated {
+		h.Bridge.Mirror(m)
+	}
+}
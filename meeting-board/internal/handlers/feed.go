@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// feedGroup is one channel's slice of a feed.
+type feedGroup struct {
+	ChannelID primitive.ObjectID `json:"channel_id"`
+	Channel   string             `json:"channel"`
+	ReadSeq   int64              `json:"read_seq"`
+	LastSeq   int64              `json:"last_seq"`
+	Items     []store.FeedItem   `json:"items"`
+}
+
+// Feed handles GET /api/feed.
+// Returns everything the caller has not read yet in one call: unread messages
+// from others in the channels it follows, plus messages anywhere it can see
+// that mention it or reply to a thread it has posted in. Each message appears
+// once, tagged with its reasons, and the result is grouped by channel in the
+// order each channel's oldest item arrived.
+//
+// Query params: channels (comma-separated names or IDs; default every
+// channel the caller can see that is not archived), limit (default 200), and
+// advance=true to move the caller's read marker in each returned followed
+// channel to its last_seq. Other channels' groups hold only mentions and
+// thread replies, so those items are marked seen instead and the rest of
+// the channel stays unread.
+func (h *Handlers) Feed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	reader := getAuthor(r)
+
+	channels, err := h.Store.ListChannels(ctx)
+	if err != nil {
+		log.Printf("handler: feed: list channels: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build feed")
+		return
+	}
+	stats, err := h.Store.GetChannelStats(ctx, reader)
+	if err != nil {
+		log.Printf("handler: feed: channel stats: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build feed")
+		return
+	}
+
+	query := store.FeedQuery{
+		Reader:    reader,
+		Aliases:   []string{reader},
+		Positions: make(map[primitive.ObjectID]int64),
+		Limit:     queryLimit(r, 200),
+	}
+	if info := getAuthorInfo(r); info != nil && info.Role != "" && info.Role != info.ID {
+		query.Aliases = append(query.Aliases, info.Role)
+	}
+
+	byID := make(map[primitive.ObjectID]*models.Channel, len(channels))
+	for i := range channels {
+		ch := &channels[i]
+		if !h.callerCanAccess(r, ch) {
+			continue
+		}
+		byID[ch.ID] = ch
+		if st, ok := stats[ch.ID]; ok {
+			query.Positions[ch.ID] = st.ReadSeq
+		}
+	}
+
+	if refs := q.Get("channels"); refs != "" {
+		for _, ref := range strings.Split(refs, ",") {
+			if ref = strings.TrimSpace(ref); ref == "" {
+				continue
+			}
+			ch := h.channelFromRef(w, r, ref)
+			if ch == nil {
+				return
+			}
+			query.Followed = append(query.Followed, ch.ID)
+		}
+	} else {
+		for id, ch := range byID {
+			if !ch.Archived {
+				query.Followed = append(query.Followed, id)
+			}
+		}
+	}
+
+	items, hasMore, err := h.Store.Feed(ctx, query)
+	if err != nil {
+		log.Printf("handler: feed: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build feed")
+		return
+	}
+
+	itemMsgs := make([]*models.Message, len(items))
+	for i := range items {
+		itemMsgs[i] = &items[i].Message
+	}
+	h.addTicketContext(ctx, itemMsgs...)
+
+	var groups []*feedGroup
+	groupOf := make(map[primitive.ObjectID]*feedGroup)
+	for _, item := range items {
+		g, ok := groupOf[item.ChannelID]
+		if !ok {
+			g = &feedGroup{
+				ChannelID: item.ChannelID,
+				Channel:   byID[item.ChannelID].Name,
+				ReadSeq:   query.Positions[item.ChannelID],
+			}
+			groupOf[item.ChannelID] = g
+			groups = append(groups, g)
+		}
+		g.Items = append(g.Items, item)
+		g.LastSeq = max(g.LastSeq, item.Seq)
+	}
+
+	advance := q.Get("advance") == "true"
+	if advance {
+		followed := make(map[primitive.ObjectID]bool, len(query.Followed))
+		for _, id := range query.Followed {
+			followed[id] = true
+		}
+		for _, g := range groups {
+			if g.LastSeq <= g.ReadSeq {
+				continue
+			}
+			if followed[g.ChannelID] {
+				err = h.Store.AdvanceReadMarker(ctx, reader, g.ChannelID, g.LastSeq)
+			} else {
+				seen := make([]models.SeenMessage, len(g.Items))
+				for i, item := range g.Items {
+					seen[i] = models.SeenMessage{ID: item.ID, Seq: item.Seq}
+				}
+				err = h.Store.MarkFeedSeen(ctx, reader, g.ChannelID, seen)
+			}
+			if err != nil {
+				log.Printf("handler: feed: advance read marker: %v", err)
+				respondError(w, http.StatusInternalServerError, "failed to advance read markers")
+				return
+			}
+		}
+	}
+
+	if wantsCompact(r) {
+		t := h.newTranscript(ctx, itemMsgs)
+		for _, g := range groups {
+			if g.ReadSeq > 0 {
+				t.heading("#%s (after #%d)", g.Channel, g.ReadSeq)
+			} else {
+				t.heading("#%s", g.Channel)
+			}
+			for i := range g.Items {
+				t.message(&g.Items[i].Message, feedReasons(g.Items[i].Reasons))
+			}
+		}
+		t.footer("total", len(items), "has_more", hasMore, "advanced", advance)
+		t.respond(w, r)
+		return
+	}
+	if groups == nil {
+		groups = []*feedGroup{}
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"channels": groups,
+		"total":    len(items),
+		"has_more": hasMore,
+		"advanced": advance,
+	})
+}
+
+// feedReasons renders a feed item's reasons for a compact transcript as
+// "{mention thread}". Plain unread channel traffic needs no annotation.
+func feedReasons(reasons []string) string {
+	var notable []string
+	for _, reason := range reasons {
+		if reason != store.FeedReasonChannel {
+			notable = append(notable, reason)
+		}
+	}
+	if len(notable) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(notable, " ") + "}"
+}
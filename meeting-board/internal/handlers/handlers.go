@@ -3,23 +3,37 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/devteam/meeting-board/internal/bridge"
 	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/planning"
 	"github.com/devteam/meeting-board/internal/store"
+	"github.com/devteam/meeting-board/internal/summary"
+	"github.com/devteam/meeting-board/internal/webhooks"
 	"github.com/devteam/meeting-board/internal/ws"
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type contextKey string
 
+// DefaultClearRetention is how long cleared channel messages stay restorable
+// when Handlers.ClearRetention is unset.
+const DefaultClearRetention = 7 * 24 * time.Hour
+
 const authorKey contextKey = "author"
 const authorInfoKey contextKey = "authorInfo"
 
@@ -29,6 +43,35 @@ type Handlers struct {
 	Hub    *ws.Hub
 	Tokens map[string]string // role (or agentID) -> bearer token (legacy)
 
+	// ClearRetention is how long cleared channel messages stay restorable.
+	// Zero means DefaultClearRetention.
+	ClearRetention time.Duration
+
+	// Tokenizer estimates token counts for context windows. Nil means a
+	// CharTokenizer with its default ratio.
+	Tokenizer Tokenizer
+
+	// Summarizer condenses threads and channels for the summary endpoints.
+	// Nil means summary.Extractive.
+	Summarizer summary.Summarizer
+
+	// PlanningBoard looks up the tickets messages reference, to annotate
+	// them with the ticket's live status. Nil disables ticket context.
+	PlanningBoard planning.Board
+
+	// TicketThreads makes the first public message referencing a ticket its
+	// canonical discussion thread and links later references to it.
+	TicketThreads bool
+
+	// Webhooks delivers published events to webhook subscriptions. Nil
+	// disables the webhook endpoints.
+	Webhooks *webhooks.Dispatcher
+
+	// Bridge mirrors the bridged channel to Discord or Slack, and
+	// BridgeSecret authenticates replies relayed back. Nil disables both.
+	Bridge       *bridge.Bridge
+	BridgeSecret string
+
 	// Registry-based auth (new)
 	mu             sync.RWMutex
 	agents         []models.AgentInfo
@@ -77,11 +120,12 @@ func (h *Handlers) SetAgents(agents []models.AgentInfo) {
 	// Support @everyone
 	mentionNames = append(mentionNames, "everyone")
 
+	// A handle must end the word, so @devops is not @dev.
 	if len(mentionNames) > 0 {
-		pattern := `@(` + strings.Join(mentionNames, "|") + `)`
+		pattern := `@(` + strings.Join(mentionNames, "|") + `)(?:[^\w-]|$)`
 		h.mentionRe = regexp.MustCompile(`(?i)` + pattern)
 	} else {
-		h.mentionRe = regexp.MustCompile(`@(po|dev|cq|qa|ops)`)
+		h.mentionRe = regexp.MustCompile(`@(po|dev|cq|qa|ops)(?:[^\w-]|$)`)
 	}
 }
 
@@ -108,61 +152,54 @@ func respondError(w http.ResponseWriter, status int, msg string) {
 	respondJSON(w, status, map[string]string{"error": msg})
 }
 
+// authenticate resolves a bearer token to an author (agent ID or legacy role)
+// and, for registry agents, the full AgentInfo. An empty token or the special
+// "dashboard" token resolves to the manager. ok is false for unknown tokens.
+func (h *Handlers) authenticate(token string) (author string, info *models.AgentInfo, ok bool) {
+	if token == "" || token == "dashboard" {
+		return "manager", nil, true
+	}
+
+	// Try registry-based lookup first
+	h.mu.RLock()
+	agent, agentOk := h.tokenToAgent[token]
+	h.mu.RUnlock()
+	if agentOk {
+		return agent.ID, agent, true
+	}
+
+	// Fall back to legacy token->role lookup
+	for role, t := range h.Tokens {
+		if t == token {
+			return role, nil, true
+		}
+	}
+	return "", nil, false
+}
+
 // AuthMiddleware extracts the Bearer token from the Authorization header,
 // resolves the author (agent ID or role), and injects it into the request context.
 // Dashboard requests (no auth) are treated as the manager.
 func (h *Handlers) AuthMiddleware(next http.Handler) http.Handler {
-	// Build legacy token->role map
-	tokenToRole := make(map[string]string, len(h.Tokens))
-	for role, token := range h.Tokens {
-		tokenToRole[token] = role
-	}
-
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 
-		// No auth header -> manager (dashboard user).
-		if authHeader == "" {
-			ctx := context.WithValue(r.Context(), authorKey, "manager")
-			next.ServeHTTP(w, r.WithContext(ctx))
-			return
-		}
-
 		// Check for "Bearer <token>" format.
-		if !strings.HasPrefix(authHeader, "Bearer ") {
+		if authHeader != "" && !strings.HasPrefix(authHeader, "Bearer ") {
 			respondError(w, http.StatusUnauthorized, "invalid authorization header format")
 			return
 		}
 
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-
-		// Special dashboard token.
-		if token == "dashboard" {
-			ctx := context.WithValue(r.Context(), authorKey, "manager")
-			next.ServeHTTP(w, r.WithContext(ctx))
-			return
-		}
-
-		// Try registry-based lookup first
-		h.mu.RLock()
-		agent, agentOk := h.tokenToAgent[token]
-		h.mu.RUnlock()
-
-		if agentOk {
-			ctx := context.WithValue(r.Context(), authorKey, agent.ID)
-			ctx = context.WithValue(ctx, authorInfoKey, agent)
-			next.ServeHTTP(w, r.WithContext(ctx))
-			return
-		}
-
-		// Fall back to legacy token->role lookup
-		role, ok := tokenToRole[token]
+		author, info, ok := h.authenticate(strings.TrimPrefix(authHeader, "Bearer "))
 		if !ok {
 			respondError(w, http.StatusUnauthorized, "invalid token")
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), authorKey, role)
+		ctx := context.WithValue(r.Context(), authorKey, author)
+		if info != nil {
+			ctx = context.WithValue(ctx, authorInfoKey, info)
+		}
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -183,11 +220,161 @@ func getAuthorInfo(r *http.Request) *models.AgentInfo {
 	return nil
 }
 
+// resolveChannel looks up a channel by ObjectID hex or by name. A leading "#"
+// on the name is ignored, so "standup", "#standup" and the channel's ID all
+// resolve to the same channel.
+func (h *Handlers) resolveChannel(ctx context.Context, ref string) (*models.Channel, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := primitive.ObjectIDFromHex(ref); err == nil {
+		ch, err := h.Store.GetChannelByID(ctx, id)
+		if err != mongo.ErrNoDocuments {
+			return ch, err
+		}
+		// A channel may legitimately be named like an ObjectID; fall through.
+	}
+	return h.Store.GetChannelByName(ctx, strings.TrimPrefix(ref, "#"))
+}
+
+// channelFromRequest resolves the {id} path variable to a channel, writing an
+// error response and returning nil if it cannot be resolved.
+func (h *Handlers) channelFromRequest(w http.ResponseWriter, r *http.Request) *models.Channel {
+	return h.channelFromRef(w, r, mux.Vars(r)["id"])
+}
+
+// channelFromRef resolves a channel ID or name for the caller, writing an error
+// response and returning nil if it cannot be resolved. Private channels the
+// caller cannot access are reported as not found.
+func (h *Handlers) channelFromRef(w http.ResponseWriter, r *http.Request, ref string) *models.Channel {
+	ch, err := h.resolveChannel(r.Context(), ref)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "channel not found: "+strings.TrimPrefix(strings.TrimSpace(ref), "#"))
+			return nil
+		}
+		log.Printf("handler: resolve channel %q: %v", ref, err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve channel")
+		return nil
+	}
+	if !h.callerCanAccess(r, ch) {
+		respondError(w, http.StatusNotFound, "channel not found: "+strings.TrimPrefix(strings.TrimSpace(ref), "#"))
+		return nil
+	}
+	return ch
+}
+
+// canAccessChannel reports whether an author (with an optional role) may see
+// ch. Public channels are open to everyone; private channels only to their
+// creator, their members (by agent ID or role) and the manager.
+func canAccessChannel(ch *models.Channel, author, role string) bool {
+	if !ch.IsPrivate() || author == "manager" || author == ch.CreatedBy {
+		return true
+	}
+	for _, m := range ch.Members {
+		if m == author || (role != "" && m == role) {
+			return true
+		}
+	}
+	return false
+}
+
+// callerCanAccess reports whether the authenticated caller may see ch.
+func (h *Handlers) callerCanAccess(r *http.Request, ch *models.Channel) bool {
+	role := ""
+	if info := getAuthorInfo(r); info != nil {
+		role = info.Role
+	}
+	return canAccessChannel(ch, getAuthor(r), role)
+}
+
+// callerCanManage reports whether the caller may edit or archive ch: the
+// manager, the PO (the meeting leader) or the channel's creator.
+func (h *Handlers) callerCanManage(r *http.Request, ch *models.Channel) bool {
+	author := getAuthor(r)
+	if author == "manager" || author == "po" || (ch.CreatedBy != "" && author == ch.CreatedBy) {
+		return true
+	}
+	info := getAuthorInfo(r)
+	return info != nil && info.Role == "po"
+}
+
+// agentID resolves an agent ID or display name to the agent's ID. Unknown
+// handles (e.g. legacy roles) are returned lower-cased; "" stays "".
+func (h *Handlers) agentID(handle string) string {
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if a, ok := h.nameToAgent[name]; ok {
+		return a.ID
+	}
+	return name
+}
+
+// roleOf returns the registry role for an agent ID, or "" if unknown.
+func (h *Handlers) roleOf(author string) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if a, ok := h.nameToAgent[strings.ToLower(author)]; ok {
+		return a.Role
+	}
+	return ""
+}
+
+// resolveMembers normalises a channel member list: agent names are resolved
+// to IDs, other handles (e.g. roles) are lower-cased, and duplicates dropped.
+func (h *Handlers) resolveMembers(members []string) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[string]bool, len(members))
+	result := make([]string, 0, len(members))
+	for _, m := range members {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(m), "@"))
+		if name == "" {
+			continue
+		}
+		if a, ok := h.nameToAgent[name]; ok {
+			name = a.ID
+		}
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// CanSubscribe reports whether a WebSocket client authenticated as identity
+// may subscribe to the channel with the given ID. It is installed as the
+// hub's Authorize hook.
+func (h *Handlers) CanSubscribe(identity, channelID string) bool {
+	id, err := primitive.ObjectIDFromHex(channelID)
+	if err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch, err := h.Store.GetChannelByID(ctx, id)
+	if err != nil {
+		return false
+	}
+	return canAccessChannel(ch, identity, h.roleOf(identity))
+}
+
 // ---------------------------------------------------------------------------
 // Channel handlers
 // ---------------------------------------------------------------------------
 
+// channelResponse is a channel plus its activity stats for the caller.
+type channelResponse struct {
+	models.Channel
+	models.ChannelStats
+}
+
 // ListChannels handles GET /api/channels.
+// Each channel includes its message count, last message time and author, and
+// the number of messages unread by the caller. Private channels the caller is
+// not a member of are omitted, as are archived channels unless
+// include_archived=true is given.
 func (h *Handlers) ListChannels(w http.ResponseWriter, r *http.Request) {
 	channels, err := h.Store.ListChannels(r.Context())
 	if err != nil {
@@ -195,14 +382,41 @@ func (h *Handlers) ListChannels(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusInternalServerError, "failed to list channels")
 		return
 	}
-	respondJSON(w, http.StatusOK, channels)
+
+	stats, err := h.Store.GetChannelStats(r.Context(), getAuthor(r))
+	if err != nil {
+		log.Printf("handler: channel stats: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list channels")
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	result := make([]channelResponse, 0, len(channels))
+	for _, ch := range channels {
+		if (ch.Archived && !includeArchived) || !h.callerCanAccess(r, &ch) {
+			continue
+		}
+		resp := channelResponse{Channel: ch}
+		if st, ok := stats[ch.ID]; ok {
+			resp.ChannelStats = *st
+		}
+		result = append(result, resp)
+	}
+	respondJSON(w, http.StatusOK, result)
 }
 
 // CreateChannel handles POST /api/channels.
+// Body: {"name", "description", "purpose", "members", "visibility"}. The caller
+// is recorded as the creator and can always see the channel.
 func (h *Handlers) CreateChannel(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name        string                  `json:"name"`
+		Description string                  `json:"description"`
+		Purpose     string                  `json:"purpose"`
+		Topic       string                  `json:"topic"`
+		Members     []string                `json:"members"`
+		Visibility  string                  `json:"visibility"`
+		Retention   *models.RetentionPolicy `json:"retention"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "invalid request body")
@@ -214,9 +428,28 @@ func (h *Handlers) CreateChannel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	visibility, ok := parseVisibility(req.Visibility)
+	if !ok {
+		respondError(w, http.StatusBadRequest, "visibility must be \"public\" or \"private\"")
+		return
+	}
+	if req.Retention != nil {
+		if msg := validateRetention(req.Retention); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	author := getAuthor(r)
 	ch := &models.Channel{
-		Name:        strings.TrimSpace(req.Name),
+		Name:        strings.TrimPrefix(strings.TrimSpace(req.Name), "#"),
 		Description: strings.TrimSpace(req.Description),
+		Purpose:     strings.TrimSpace(req.Purpose),
+		Topic:       strings.TrimSpace(req.Topic),
+		Members:     h.resolveMembers(req.Members),
+		Visibility:  visibility,
+		CreatedBy:   author,
+		Retention:   req.Retention,
 	}
 
 	if err := h.Store.CreateChannel(r.Context(), ch); err != nil {
@@ -229,219 +462,579 @@ func (h *Handlers) CreateChannel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	author := getAuthor(r)
 	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
 		Actor:  author,
 		Action: "channel.create",
 		Details: map[string]any{
 			"channel_id":   ch.ID.Hex(),
 			"channel_name": ch.Name,
+			"visibility":   ch.Visibility,
+			"members":      ch.Members,
 		},
 	})
 
 	respondJSON(w, http.StatusCreated, ch)
 }
 
-// ---------------------------------------------------------------------------
-// Message handlers
-// ---------------------------------------------------------------------------
+// UpdateChannel handles PATCH /api/channels/{id}.
+// Accepts any of {"description", "purpose", "topic", "members", "visibility",
+// "archived", "retention"}; omitted fields are left unchanged and a null retention reverts
+// the channel to the server default. Only the manager, the PO or the channel's
+// creator may edit a channel.
+func (h *Handlers) UpdateChannel(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	if !h.callerCanManage(r, ch) {
+		respondError(w, http.StatusForbidden, "only the channel creator, PO or manager can edit this channel")
+		return
+	}
 
-// ListMessages handles GET /api/channels/{id}/messages.
-func (h *Handlers) ListMessages(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	channelID, err := primitive.ObjectIDFromHex(vars["id"])
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "invalid channel id")
+	var req struct {
+		Description *string         `json:"description"`
+		Purpose     *string         `json:"purpose"`
+		Topic       *string         `json:"topic"`
+		Members     *[]string       `json:"members"`
+		Visibility  *string         `json:"visibility"`
+		Archived    *bool           `json:"archived"`
+		Retention   json.RawMessage `json:"retention"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	var since *time.Time
-	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
-		t, err := time.Parse(time.RFC3339, sinceStr)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid since parameter, use RFC3339 format")
+	set := bson.M{}
+	if req.Description != nil {
+		set["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.Purpose != nil {
+		set["purpose"] = strings.TrimSpace(*req.Purpose)
+	}
+	if req.Topic != nil {
+		set["topic"] = strings.TrimSpace(*req.Topic)
+	}
+	if req.Members != nil {
+		set["members"] = h.resolveMembers(*req.Members)
+	}
+	if req.Visibility != nil {
+		visibility, ok := parseVisibility(*req.Visibility)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "visibility must be \"public\" or \"private\"")
 			return
 		}
-		since = &t
+		set["visibility"] = visibility
 	}
-
-	limit := int64(50)
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		l, err := strconv.ParseInt(limitStr, 10, 64)
-		if err == nil && l > 0 {
-			limit = l
+	if req.Archived != nil {
+		set["archived"] = *req.Archived
+		set["archived_at"] = nil
+		if *req.Archived {
+			set["archived_at"] = time.Now().UTC()
+		}
+	}
+	if req.Retention != nil {
+		var policy *models.RetentionPolicy
+		if err := json.Unmarshal(req.Retention, &policy); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid retention policy")
+			return
+		}
+		if policy != nil {
+			if msg := validateRetention(policy); msg != "" {
+				respondError(w, http.StatusBadRequest, msg)
+				return
+			}
 		}
+		set["retention"] = policy
+	}
+	if len(set) == 0 {
+		respondError(w, http.StatusBadRequest, "no updatable fields provided")
+		return
 	}
 
-	messages, err := h.Store.ListMessages(r.Context(), channelID, since, limit)
+	updated, err := h.Store.UpdateChannel(r.Context(), ch.ID, set)
 	if err != nil {
-		log.Printf("handler: list messages: %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		log.Printf("handler: update channel: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to update channel")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, messages)
+	changed := make([]string, 0, len(set))
+	for field := range set {
+		changed = append(changed, field)
+	}
+	sort.Strings(changed)
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  getAuthor(r),
+		Action: "channel.update",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"fields":       changed,
+		},
+	})
+
+	h.publish(ch.ID, EventChannelUpdated, updated)
+
+	respondJSON(w, http.StatusOK, updated)
 }
 
-// PostMessage handles POST /api/channels/{id}/messages.
-func (h *Handlers) PostMessage(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	channelID, err := primitive.ObjectIDFromHex(vars["id"])
+// ArchiveChannel handles POST /api/channels/{id}/archive.
+// Archived channels are read-only and hidden from ListChannels by default.
+func (h *Handlers) ArchiveChannel(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	if !h.callerCanManage(r, ch) {
+		respondError(w, http.StatusForbidden, "only the channel creator, PO or manager can archive this channel")
+		return
+	}
+
+	updated, err := h.Store.SetChannelArchived(r.Context(), ch.ID, true)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "invalid channel id")
+		log.Printf("handler: archive channel: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to archive channel")
 		return
 	}
 
-	var req struct {
-		Content  string `json:"content"`
-		ThreadID string `json:"thread_id,omitempty"`
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  getAuthor(r),
+		Action: "channel.archive",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+		},
+	})
+
+	h.publish(ch.ID, EventChannelUpdated, updated)
+
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// parseVisibility validates a channel visibility value, defaulting to public.
+func parseVisibility(v string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", models.ChannelPublic:
+		return models.ChannelPublic, true
+	case models.ChannelPrivate:
+		return models.ChannelPrivate, true
+	default:
+		return "", false
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "invalid request body")
+}
+
+// validateRetention checks a channel retention policy and returns a message
+// describing the problem, or "" if it is valid.
+func validateRetention(p *models.RetentionPolicy) string {
+	switch {
+	case p.MaxAgeDays < 0 || p.MaxCount < 0:
+		return "retention limits must not be negative"
+	case p.KeepForever && (p.MaxAgeDays > 0 || p.MaxCount > 0):
+		return "retention keep_forever cannot be combined with max_age_days or max_count"
+	case !p.KeepForever && p.MaxAgeDays == 0 && p.MaxCount == 0:
+		return "retention needs keep_forever, max_age_days or max_count"
+	}
+	return ""
+}
+
+// ---------------------------------------------------------------------------
+// Message handlers
+// ---------------------------------------------------------------------------
+
+// ListMessages handles GET /api/channels/{id}/messages.
+// The {id} segment may be a channel ObjectID or a channel name. Responds with
+// a page envelope; see listChannelMessages.
+func (h *Handlers) ListMessages(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
 		return
 	}
+	h.listChannelMessages(w, r, ch)
+}
 
-	if strings.TrimSpace(req.Content) == "" {
-		respondError(w, http.StatusBadRequest, "message content is required")
+// listChannelMessages responds with a page of top-level messages in ch,
+// oldest first, as {"messages": [...], "next_cursor", "prev_cursor", "has_more"}.
+// Without a cursor or since, the newest page is returned. author (agent ID
+// or name) restricts the page to one author.
+//
+// With after_seq=N it instead returns every message (thread replies included)
+// with a sequence number above N in seq order, as {"messages", "last_seq",
+// "has_more"}, for clients backfilling gaps detected in the seq stream.
+//
+// With unread=true it returns the caller's unread messages in the same
+// shape; see listUnreadMessages.
+func (h *Handlers) listChannelMessages(w http.ResponseWriter, r *http.Request, ch *models.Channel) {
+	if r.URL.Query().Get("unread") == "true" {
+		h.listUnreadMessages(w, r, ch)
+		return
+	}
+	if afterSeqStr := r.URL.Query().Get("after_seq"); afterSeqStr != "" {
+		h.listMessagesAfterSeq(w, r, ch, afterSeqStr)
 		return
 	}
 
-	author := getAuthor(r)
-	authorInfo := getAuthorInfo(r)
+	page, err := parsePage(r, 50)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	// Parse @mentions from the content using dynamic regex.
-	h.mu.RLock()
-	mentionRe := h.mentionRe
-	h.mu.RUnlock()
+	author := h.agentID(r.URL.Query().Get("author"))
+	messages, info, err := h.Store.ListMessages(r.Context(), ch.ID, author, page)
+	if err != nil {
+		log.Printf("handler: list messages: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+	h.addTicketContext(r.Context(), messagePtrs(messages)...)
 
-	mentionSet := make(map[string]bool)
-	if mentionRe != nil {
-		matches := mentionRe.FindAllStringSubmatch(req.Content, -1)
-		for _, m := range matches {
-			mentioned := strings.ToLower(m[1])
-			if mentioned == "everyone" {
-				// Expand @everyone to all registered agents
-				h.mu.RLock()
-				for _, agent := range h.agents {
-					mentionSet[agent.ID] = true
-				}
-				h.mu.RUnlock()
-			} else {
-				// Resolve name to ID if possible
-				h.mu.RLock()
-				if agent, ok := h.nameToAgent[mentioned]; ok {
-					mentionSet[agent.ID] = true
-				} else {
-					mentionSet[mentioned] = true
-				}
-				h.mu.RUnlock()
-			}
-		}
+	if wantsCompact(r) {
+		h.respondCompactMessages(w, r, ch, messages,
+			"has_more", info.HasMore, "next_cursor", info.NextCursor, "prev_cursor", info.PrevCursor)
+		return
 	}
-	mentions := make([]string, 0, len(mentionSet))
-	for m := range mentionSet {
-		mentions = append(mentions, m)
+	respondPage(w, "messages", messages, info)
+}
+
+// listMessagesAfterSeq implements the after_seq form of listChannelMessages.
+func (h *Handlers) listMessagesAfterSeq(w http.ResponseWriter, r *http.Request, ch *models.Channel, afterSeqStr string) {
+	afterSeq, err := strconv.ParseInt(afterSeqStr, 10, 64)
+	if err != nil || afterSeq < 0 {
+		respondError(w, http.StatusBadRequest, "invalid after_seq parameter, use a non-negative integer")
+		return
 	}
+	limit := queryLimit(r, 50)
 
-	msg := &models.Message{
-		ChannelID: channelID,
-		Author:    author,
-		Content:   req.Content,
-		Mentions:  mentions,
+	messages, hasMore, err := h.Store.ListMessagesAfterSeq(r.Context(), ch.ID, afterSeq, limit)
+	if err != nil {
+		log.Printf("handler: list messages after seq: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
 	}
 
-	// Set display name and role from registry
-	if authorInfo != nil {
-		msg.AuthorName = authorInfo.Name
-		msg.AuthorRole = authorInfo.Role
-	} else if author == "manager" {
-		h.mu.RLock()
-		msg.AuthorName = h.managerName
-		h.mu.RUnlock()
-		msg.AuthorRole = "manager"
+	lastSeq := afterSeq
+	if len(messages) > 0 {
+		lastSeq = messages[len(messages)-1].Seq
+	}
+	h.addTicketContext(r.Context(), messagePtrs(messages)...)
+	if wantsCompact(r) {
+		h.respondCompactMessages(w, r, ch, messages, "last_seq", lastSeq, "has_more", hasMore)
+		return
 	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"messages": messages,
+		"last_seq": lastSeq,
+		"has_more": hasMore,
+	})
+}
 
-	if req.ThreadID != "" {
-		tid, err := primitive.ObjectIDFromHex(req.ThreadID)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid thread_id")
-			return
-		}
-		msg.ThreadID = &tid
+// PostMessage handles POST /api/channels/{id}/messages.
+// The {id} segment may be a channel ObjectID or a channel name. The body is
+// decoded by decodeMessageRequest; see messageRequest for accepted fields.
+func (h *Handlers) PostMessage(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+
+	req, warnings, err := decodeMessageRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Channel != "" {
+		warnings = append(warnings, `field "channel" ignored: channel is taken from the URL`)
+	}
+
+	h.createMessage(w, r, ch, req, warnings)
+}
+
+// ClearChannel handles DELETE /api/channels/{id}/messages.
+// Messages are not destroyed: they move to an archive under a clear operation
+// that can be restored with RestoreClear until ClearRetention has elapsed.
+func (h *Handlers) ClearChannel(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
 	}
 
-	if err := h.Store.CreateMessage(r.Context(), msg); err != nil {
-		log.Printf("handler: create message: %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to create message")
+	author := getAuthor(r)
+	op, err := h.Store.ClearChannel(r.Context(), ch, author, h.clearRetention())
+	if err != nil {
+		log.Printf("handler: clear channel: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to clear channel")
 		return
 	}
 
-	// Audit entry.
 	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
 		Actor:  author,
-		Action: "message.post",
+		Action: "channel.clear",
 		Details: map[string]any{
-			"channel_id": channelID.Hex(),
-			"message_id": msg.ID.Hex(),
-			"mentions":   mentions,
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"clear_id":     op.ID.Hex(),
+			"deleted":      op.MessageCount,
 		},
 	})
 
-	// Broadcast over WebSocket.
-	payload, err := json.Marshal(msg)
-	if err == nil {
-		h.Hub.Broadcast(channelID.Hex(), payload)
+	h.publish(ch.ID, EventChannelCleared, op)
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"deleted":          op.MessageCount,
+		"clear_id":         op.ID.Hex(),
+		"restorable_until": op.ExpiresAt,
+	})
+}
+
+// ListClears handles GET /api/channels/{id}/clears.
+// Lists the channel's clear operations, newest first, with whether each is
+// still restorable.
+func (h *Handlers) ListClears(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+
+	ops, err := h.Store.ListClears(r.Context(), ch.ID)
+	if err != nil {
+		log.Printf("handler: list clears: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list clears")
+		return
 	}
 
-	respondJSON(w, http.StatusCreated, msg)
+	type clearResponse struct {
+		models.ClearOperation
+		Restorable bool `json:"restorable"`
+	}
+	now := time.Now()
+	result := make([]clearResponse, len(ops))
+	for i, op := range ops {
+		result[i] = clearResponse{
+			ClearOperation: op,
+			Restorable:     op.RestoredAt == nil && now.Before(op.ExpiresAt),
+		}
+	}
+	respondJSON(w, http.StatusOK, result)
 }
 
-// ClearChannel handles DELETE /api/channels/{id}/messages.
-func (h *Handlers) ClearChannel(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	channelID, err := primitive.ObjectIDFromHex(vars["id"])
+// RestoreClear handles POST /api/clears/{id}/restore.
+// Moves the messages removed by a clear back into their channel. Fails with
+// 409 if already restored and 410 once the retention window has passed.
+func (h *Handlers) RestoreClear(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "invalid channel id")
+		respondError(w, http.StatusBadRequest, "invalid clear id")
 		return
 	}
 
-	deleted, err := h.Store.DeleteChannelMessages(r.Context(), channelID)
+	op, err := h.Store.GetClear(r.Context(), id)
 	if err != nil {
-		log.Printf("handler: clear channel: %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to clear channel")
+		respondError(w, http.StatusNotFound, "clear operation not found")
+		return
+	}
+	ch := h.channelFromRef(w, r, op.ChannelID.Hex())
+	if ch == nil {
 		return
 	}
 
 	author := getAuthor(r)
+	op, err = h.Store.RestoreClear(r.Context(), id, author)
+	switch err {
+	case nil:
+	case store.ErrClearRestored:
+		respondError(w, http.StatusConflict, "clear operation already restored")
+		return
+	case store.ErrClearExpired:
+		respondError(w, http.StatusGone, "clear operation is past its retention window")
+		return
+	default:
+		log.Printf("handler: restore clear: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to restore clear")
+		return
+	}
+
 	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
 		Actor:  author,
-		Action: "channel.clear",
+		Action: "channel.restore",
 		Details: map[string]any{
-			"channel_id": channelID.Hex(),
-			"deleted":    deleted,
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"clear_id":     op.ID.Hex(),
+			"restored":     op.MessageCount,
 		},
 	})
 
-	respondJSON(w, http.StatusOK, map[string]any{"deleted": deleted})
+	h.publish(ch.ID, EventChannelRestored, op)
+
+	respondJSON(w, http.StatusOK, op)
+}
+
+// clearRetention returns how long cleared messages remain restorable.
+func (h *Handlers) clearRetention() time.Duration {
+	if h.ClearRetention > 0 {
+		return h.ClearRetention
+	}
+	return DefaultClearRetention
 }
 
 // ListThreads handles GET /api/channels/{id}/threads.
+// Responds with a page envelope of thread roots, newest first, each including
+// reply_count, last_reply_at and participants.
 func (h *Handlers) ListThreads(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	channelID, err := primitive.ObjectIDFromHex(vars["id"])
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	page, err := parsePage(r, 50)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "invalid channel id")
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	roots, err := h.Store.ListThreadRoots(r.Context(), channelID)
+	roots, info, err := h.Store.ListThreadRoots(r.Context(), ch.ID, page)
 	if err != nil {
 		log.Printf("handler: list threads: %v", err)
 		respondError(w, http.StatusInternalServerError, "failed to list threads")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, roots)
+	if wantsCompact(r) {
+		msgs := make([]*models.Message, len(roots))
+		for i := range roots {
+			msgs[i] = &roots[i].Message
+		}
+		t := h.newTranscript(r.Context(), msgs)
+		t.heading("#%s threads", ch.Name)
+		for i := range roots {
+			t.message(msgs[i], fmt.Sprintf("{%d replies}", roots[i].ReplyCount))
+		}
+		t.footer("has_more", info.HasMore, "next_cursor", info.NextCursor, "prev_cursor", info.PrevCursor)
+		t.respond(w, r)
+		return
+	}
+	respondPage(w, "threads", roots, info)
+}
+
+// messageDetail is a single message with its channel name and a dashboard permalink.
+type messageDetail struct {
+	models.Message
+	Channel   string `json:"channel"`
+	Permalink string `json:"permalink"`
+}
+
+// GetMessage handles GET /api/messages/{id}.
+func (h *Handlers) GetMessage(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	h.addTicketContext(r.Context(), msg)
+
+	respondJSON(w, http.StatusOK, messageDetail{
+		Message:   *msg,
+		Channel:   ch.Name,
+		Permalink: permalink(r, ch.Name, msg.ID),
+	})
+}
+
+// GetThread handles GET /api/threads/{id}.
+// Returns the thread root with its reply statistics plus a page of replies
+// (oldest first). {id} may be the root or any reply in the thread. Query
+// params: offset (default 0) and limit (default 50, at most 500); malformed
+// values are rejected with 400.
+func (h *Handlers) GetThread(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	rootID := msg.ID
+	if msg.ThreadID != nil {
+		rootID = *msg.ThreadID
+	}
+
+	offset, limit, err := parseOffsetLimit(r, 50)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	root, err := h.Store.GetThreadSummary(r.Context(), rootID)
+	if err != nil {
+		log.Printf("handler: get thread root: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get thread")
+		return
+	}
+	replies, err := h.Store.ListThreadReplies(r.Context(), rootID, offset, limit)
+	if err != nil {
+		log.Printf("handler: list thread replies: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get thread")
+		return
+	}
+	hasMore := offset+int64(len(replies)) < root.ReplyCount
+	h.addTicketContext(r.Context(), append([]*models.Message{&root.Message}, messagePtrs(replies)...)...)
+
+	if wantsCompact(r) {
+		t := h.newTranscript(r.Context(), append([]*models.Message{&root.Message}, messagePtrs(replies)...))
+		t.heading("#%s thread %s", ch.Name, shortRef("", root.Seq, root.ID))
+		t.message(&root.Message, fmt.Sprintf("{%d replies}", root.ReplyCount))
+		for i := range replies {
+			t.message(&replies[i])
+		}
+		t.footer("offset", offset, "has_more", hasMore)
+		t.respond(w, r)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"root":      root,
+		"channel":   ch.Name,
+		"permalink": permalink(r, ch.Name, rootID),
+		"replies":   replies,
+		"offset":    offset,
+		"limit":     limit,
+		"has_more":  hasMore,
+	})
+}
+
+// messageFromRequest resolves the {id} path variable to a message and its
+// channel, writing an error response and returning nils if the message does
+// not exist or is in a channel the caller cannot access.
+func (h *Handlers) messageFromRequest(w http.ResponseWriter, r *http.Request) (*models.Message, *models.Channel) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid message id")
+		return nil, nil
+	}
+
+	msg, err := h.Store.GetMessageByID(r.Context(), id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "message not found")
+			return nil, nil
+		}
+		log.Printf("handler: get message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get message")
+		return nil, nil
+	}
+
+	ch, err := h.Store.GetChannelByID(r.Context(), msg.ChannelID)
+	if err != nil || !h.callerCanAccess(r, ch) {
+		respondError(w, http.StatusNotFound, "message not found")
+		return nil, nil
+	}
+	return msg, ch
+}
+
+// permalink returns a dashboard URL that opens the channel and scrolls to the message.
+func permalink(r *http.Request, channel string, id primitive.ObjectID) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s/?channel=%s#msg-%s", scheme, r.Host, url.QueryEscape(channel), id.Hex())
 }
 
 // ---------------------------------------------------------------------------
@@ -474,198 +1067,287 @@ func (h *Handlers) ListAgentsAPI(w http.ResponseWriter, r *http.Request) {
 // ---------------------------------------------------------------------------
 
 // PostMessageByName handles POST /api/messages.
-// Accepts {"channel": "#standup", "content": "..."} or {"channel": "standup", "body": "..."}.
+// Accepts {"channel": "#standup", "content": "..."} or {"channel": "standup", "body": "..."}
+// plus the optional fields described on messageRequest.
 // Resolves the channel name to an ID and creates the message.
 func (h *Handlers) PostMessageByName(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Channel  string `json:"channel"`
-		Content  string `json:"content"`
-		Body     string `json:"body"`
-		ThreadID string `json:"thread_id,omitempty"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	req, warnings, err := decodeMessageRequest(r)
+	if err != nil {
 		respondError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	// Accept both "content" and "body" fields.
-	content := req.Content
-	if content == "" {
-		content = req.Body
-	}
-	if strings.TrimSpace(content) == "" {
-		respondError(w, http.StatusBadRequest, "message content is required (use \"content\" or \"body\" field)")
+	if strings.TrimSpace(req.Channel) == "" {
+		respondError(w, http.StatusBadRequest, "channel name is required")
 		return
 	}
 
-	if strings.TrimSpace(req.Channel) == "" {
-		respondError(w, http.StatusBadRequest, "channel name is required")
+	ch := h.channelFromRef(w, r, req.Channel)
+	if ch == nil {
 		return
 	}
 
-	// Strip leading "#" from channel name.
-	channelName := strings.TrimPrefix(strings.TrimSpace(req.Channel), "#")
+	h.createMessage(w, r, ch, req, warnings)
+}
 
-	ch, err := h.Store.GetChannelByName(r.Context(), channelName)
-	if err != nil {
-		respondError(w, http.StatusNotFound, "channel not found: "+channelName)
+// ListMessagesByName handles GET /api/messages?channel=standup&limit=20&since=...
+// Accepts the same pagination parameters as ListMessages.
+func (h *Handlers) ListMessagesByName(w http.ResponseWriter, r *http.Request) {
+	channelName := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("channel")), "#")
+	if channelName == "" {
+		respondError(w, http.StatusBadRequest, "channel query parameter is required")
 		return
 	}
 
-	author := getAuthor(r)
-	authorInfo := getAuthorInfo(r)
+	ch := h.channelFromRef(w, r, channelName)
+	if ch == nil {
+		return
+	}
+	h.listChannelMessages(w, r, ch)
+}
 
-	// Parse @mentions from the content.
-	h.mu.RLock()
-	mentionRe := h.mentionRe
-	h.mu.RUnlock()
+// ---------------------------------------------------------------------------
+// Mentions handler
+// ---------------------------------------------------------------------------
 
-	mentionSet := make(map[string]bool)
-	if mentionRe != nil {
-		matches := mentionRe.FindAllStringSubmatch(content, -1)
-		for _, m := range matches {
-			mentioned := strings.ToLower(m[1])
-			if mentioned == "everyone" {
-				h.mu.RLock()
-				for _, agent := range h.agents {
-					mentionSet[agent.ID] = true
-				}
-				h.mu.RUnlock()
-			} else {
-				h.mu.RLock()
-				if agent, ok := h.nameToAgent[mentioned]; ok {
-					mentionSet[agent.ID] = true
-				} else {
-					mentionSet[mentioned] = true
-				}
-				h.mu.RUnlock()
-			}
+// GetMentions handles GET /api/mentions.
+// Returns mention inbox items addressed to the authenticated caller, or to the
+// recipients selected by the agent/persona (agent ID or name) or role query
+// parameters. Optional filters: responded (true/false) plus the pagination
+// parameters (since, before, after, offset, limit). Without since or a cursor
+// only the last 24 hours are returned, unless responded=false is given, in
+// which case every open mention is returned regardless of age. Mentions in
+// private channels the caller cannot access are left out.
+func (h *Handlers) GetMentions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := store.MentionFilter{Recipients: h.mentionRecipients(r)}
+
+	channels, err := h.Store.ListChannels(r.Context())
+	if err != nil {
+		log.Printf("handler: get mentions: list channels: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get mentions")
+		return
+	}
+	filter.ChannelIDs = []primitive.ObjectID{}
+	for i := range channels {
+		if h.callerCanAccess(r, &channels[i]) {
+			filter.ChannelIDs = append(filter.ChannelIDs, channels[i].ID)
 		}
 	}
-	mentions := make([]string, 0, len(mentionSet))
-	for m := range mentionSet {
-		mentions = append(mentions, m)
+
+	if respondedStr := q.Get("responded"); respondedStr != "" {
+		responded, err := strconv.ParseBool(respondedStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid responded parameter, use true or false")
+			return
+		}
+		filter.Responded = &responded
 	}
 
-	msg := &models.Message{
-		ChannelID: ch.ID,
-		Author:    author,
-		Content:   content,
-		Mentions:  mentions,
+	page, err := parsePage(r, 0)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if page.After == nil && page.Before == nil && (filter.Responded == nil || *filter.Responded) {
+		since := time.Now().Add(-24 * time.Hour) // Default: last 24 hours.
+		filter.Since = &since
 	}
 
-	if authorInfo != nil {
-		msg.AuthorName = authorInfo.Name
-		msg.AuthorRole = authorInfo.Role
-	} else if author == "manager" {
-		h.mu.RLock()
-		msg.AuthorName = h.managerName
-		h.mu.RUnlock()
-		msg.AuthorRole = "manager"
+	mentions, info, err := h.Store.ListMentions(r.Context(), filter, page)
+	if err != nil {
+		log.Printf("handler: get mentions: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get mentions")
+		return
 	}
+	h.fillMentionChannels(r.Context(), mentions)
 
-	if req.ThreadID != "" {
-		tid, err := primitive.ObjectIDFromHex(req.ThreadID)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid thread_id")
-			return
+	if wantsCompact(r) {
+		t := &transcript{}
+		t.heading("mentions")
+		for i := range mentions {
+			t.mention(&mentions[i])
 		}
-		msg.ThreadID = &tid
+		t.footer("has_more", info.HasMore, "next_cursor", info.NextCursor, "prev_cursor", info.PrevCursor)
+		t.respond(w, r)
+		return
+	}
+	respondPage(w, "mentions", mentions, info)
+}
+
+// AckMention handles POST /api/mentions/{id}/ack.
+// Marks a mention as responded without posting a reply. Only the mentioned
+// agent (or the manager) may acknowledge it.
+func (h *Handlers) AckMention(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid mention id")
+		return
+	}
+
+	mention, err := h.Store.GetMentionByID(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "mention not found")
+		return
 	}
 
-	if err := h.Store.CreateMessage(r.Context(), msg); err != nil {
-		log.Printf("handler: create message (by name): %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to create message")
+	author := getAuthor(r)
+	if !h.isMentionRecipient(r, mention.Recipient) {
+		respondError(w, http.StatusForbidden, "only the mentioned agent can acknowledge this mention")
+		return
+	}
+
+	mention, err = h.Store.AckMention(r.Context(), id)
+	if err != nil {
+		log.Printf("handler: ack mention: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to acknowledge mention")
 		return
 	}
 
 	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
 		Actor:  author,
-		Action: "message.post",
+		Action: "mention.ack",
 		Details: map[string]any{
-			"channel_id":   ch.ID.Hex(),
-			"channel_name": ch.Name,
-			"message_id":   msg.ID.Hex(),
-			"mentions":     mentions,
+			"mention_id": mention.ID.Hex(),
+			"message_id": mention.MessageID.Hex(),
+			"recipient":  mention.Recipient,
 		},
 	})
 
-	payload, err := json.Marshal(msg)
-	if err == nil {
-		h.Hub.Broadcast(ch.ID.Hex(), payload)
-	}
-
-	respondJSON(w, http.StatusCreated, msg)
+	acked := []models.Mention{*mention}
+	h.fillMentionChannels(r.Context(), acked)
+	respondJSON(w, http.StatusOK, acked[0])
 }
 
-// ListMessagesByName handles GET /api/messages?channel=standup&limit=20&since=...
-func (h *Handlers) ListMessagesByName(w http.ResponseWriter, r *http.Request) {
-	channelName := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("channel")), "#")
-	if channelName == "" {
-		respondError(w, http.StatusBadRequest, "channel query parameter is required")
-		return
+// mentionRecipients returns the inbox recipients selected by the request.
+// agent/persona select a single agent by ID or name; role selects every agent
+// with that role plus the bare role handle (e.g. "@po"). With neither, the
+// caller's own ID and role are used.
+func (h *Handlers) mentionRecipients(r *http.Request) []string {
+	q := r.URL.Query()
+	agent := q.Get("agent")
+	if agent == "" {
+		agent = q.Get("persona")
 	}
+	role := strings.ToLower(q.Get("role"))
 
-	ch, err := h.Store.GetChannelByName(r.Context(), channelName)
-	if err != nil {
-		respondError(w, http.StatusNotFound, "channel not found: "+channelName)
-		return
+	if agent == "" && role == "" {
+		recipients := []string{getAuthor(r)}
+		if info := getAuthorInfo(r); info != nil && info.Role != "" && info.Role != info.ID {
+			recipients = append(recipients, info.Role)
+		}
+		return recipients
 	}
 
-	var since *time.Time
-	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
-		t, err := time.Parse(time.RFC3339, sinceStr)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid since parameter, use RFC3339 format")
-			return
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var recipients []string
+	if agent != "" {
+		if a, ok := h.nameToAgent[strings.ToLower(agent)]; ok {
+			recipients = append(recipients, a.ID)
+		} else {
+			recipients = append(recipients, strings.ToLower(agent))
 		}
-		since = &t
 	}
-
-	limit := int64(50)
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		l, err := strconv.ParseInt(limitStr, 10, 64)
-		if err == nil && l > 0 {
-			limit = l
+	if role != "" {
+		recipients = append(recipients, role)
+		for _, a := range h.agents {
+			if strings.ToLower(a.Role) == role {
+				recipients = append(recipients, a.ID)
+			}
 		}
 	}
+	return recipients
+}
 
-	messages, err := h.Store.ListMessages(r.Context(), ch.ID, since, limit)
+// isMentionRecipient reports whether the caller may act on a mention addressed
+// to recipient: either it is the caller's ID or role, or the caller is the manager.
+func (h *Handlers) isMentionRecipient(r *http.Request, recipient string) bool {
+	author := getAuthor(r)
+	if author == recipient || author == "manager" {
+		return true
+	}
+	info := getAuthorInfo(r)
+	return info != nil && info.Role == recipient
+}
+
+// fillMentionChannels populates the channel name on each mention.
+func (h *Handlers) fillMentionChannels(ctx context.Context, mentions []models.Mention) {
+	if len(mentions) == 0 {
+		return
+	}
+	channels, err := h.Store.ListChannels(ctx)
 	if err != nil {
-		log.Printf("handler: list messages (by name): %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		log.Printf("handler: list channels for mentions: %v", err)
 		return
 	}
-
-	respondJSON(w, http.StatusOK, messages)
+	names := make(map[primitive.ObjectID]string, len(channels))
+	for _, ch := range channels {
+		names[ch.ID] = ch.Name
+	}
+	for i := range mentions {
+		mentions[i].Channel = names[mentions[i].ChannelID]
+	}
 }
 
 // ---------------------------------------------------------------------------
-// Mentions handler
+// Activity handler
 // ---------------------------------------------------------------------------
 
-// GetMentions handles GET /api/mentions.
-func (h *Handlers) GetMentions(w http.ResponseWriter, r *http.Request) {
-	author := getAuthor(r)
+// GetLastActivity handles GET /api/activity/last.
+// Reports the most recent message across the channels the caller can see, so
+// the PO can detect quiet periods. Fields are null when those channels have
+// no messages yet.
+func (h *Handlers) GetLastActivity(w http.ResponseWriter, r *http.Request) {
+	type activityResponse struct {
+		LastActivityTimestamp *time.Time `json:"last_activity_timestamp"`
+		Channel               string     `json:"channel,omitempty"`
+		ChannelID             string     `json:"channel_id,omitempty"`
+		Author                string     `json:"author,omitempty"`
+		AuthorName            string     `json:"author_name,omitempty"`
+		HoursAgo              *float64   `json:"hours_ago"`
+	}
 
-	since := time.Now().Add(-24 * time.Hour) // Default: last 24 hours.
-	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
-		t, err := time.Parse(time.RFC3339, sinceStr)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid since parameter, use RFC3339 format")
-			return
+	channels, err := h.Store.ListChannels(r.Context())
+	if err != nil {
+		log.Printf("handler: last activity: list channels: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get last activity")
+		return
+	}
+	names := make(map[primitive.ObjectID]string, len(channels))
+	var visible []primitive.ObjectID
+	for i := range channels {
+		if h.callerCanAccess(r, &channels[i]) {
+			names[channels[i].ID] = channels[i].Name
+			visible = append(visible, channels[i].ID)
 		}
-		since = t
 	}
 
-	messages, err := h.Store.GetMentionsSince(r.Context(), author, since)
+	msg, err := h.Store.GetLastMessage(r.Context(), visible)
+	if err == mongo.ErrNoDocuments {
+		respondJSON(w, http.StatusOK, activityResponse{})
+		return
+	}
 	if err != nil {
-		log.Printf("handler: get mentions: %v", err)
-		respondError(w, http.StatusInternalServerError, "failed to get mentions")
+		log.Printf("handler: last activity: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get last activity")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, messages)
+	hoursAgo := math.Round(time.Since(msg.CreatedAt).Hours()*100) / 100
+	resp := activityResponse{
+		LastActivityTimestamp: &msg.CreatedAt,
+		ChannelID:             msg.ChannelID.Hex(),
+		Author:                msg.Author,
+		AuthorName:            msg.AuthorName,
+		HoursAgo:              &hoursAgo,
+	}
+	if name, ok := names[msg.ChannelID]; ok {
+		resp.Channel = "#" + name
+	}
+
+	respondJSON(w, http.StatusOK, resp)
 }
 
 // ---------------------------------------------------------------------------
@@ -673,35 +1355,25 @@ func (h *Handlers) GetMentions(w http.ResponseWriter, r *http.Request) {
 // ---------------------------------------------------------------------------
 
 // ListAudit handles GET /api/audit.
+// Responds with a page envelope of entries, newest first, optionally filtered
+// by actor.
 func (h *Handlers) ListAudit(w http.ResponseWriter, r *http.Request) {
 	actor := r.URL.Query().Get("actor")
 
-	var since *time.Time
-	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
-		t, err := time.Parse(time.RFC3339, sinceStr)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid since parameter, use RFC3339 format")
-			return
-		}
-		since = &t
-	}
-
-	limit := int64(100)
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		l, err := strconv.ParseInt(limitStr, 10, 64)
-		if err == nil && l > 0 {
-			limit = l
-		}
+	page, err := parsePage(r, 100)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	entries, err := h.Store.ListAuditEntries(r.Context(), actor, since, limit)
+	entries, info, err := h.Store.ListAuditEntries(r.Context(), actor, page)
 	if err != nil {
 		log.Printf("handler: list audit: %v", err)
 		respondError(w, http.StatusInternalServerError, "failed to list audit entries")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, entries)
+	respondPage(w, "entries", entries, info)
 }
 
 // ---------------------------------------------------------------------------
@@ -718,6 +1390,18 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 // ---------------------------------------------------------------------------
 
 // HandleWebSocket handles GET /ws.
+// Clients may identify themselves with a "token" query parameter or a Bearer
+// Authorization header; without one they are treated as the manager, matching
+// the REST API. The identity is used to gate subscriptions to private channels.
 func (h *Handlers) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	ws.ServeWs(h.Hub, w, r)
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	author, _, ok := h.authenticate(token)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	ws.ServeWs(h.Hub, w, r, author)
 }
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/integrations"
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/webhooks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxHookBody caps the size of an incoming webhook request; GitHub payloads
+// and JUnit reports can be large.
+const maxHookBody = 10 << 20
+
+// integrationRole is the author role of messages posted by incoming webhooks.
+const integrationRole = "integration"
+
+// hookResponse is an incoming webhook plus the path to post to, shown only
+// when the hook is created.
+type hookResponse struct {
+	*models.IncomingHook
+	URL string `json:"url,omitempty"`
+}
+
+// CreateIncomingHook handles POST /api/channels/{id}/hooks.
+// Body: {"name", "users"}. name is the author shown on the hook's messages
+// (default "Integration"); users maps GitHub logins to the agents or roles
+// they are mentioned as. Only the channel's managers (see callerCanManage)
+// may create hooks. The response is the only place the token is shown.
+func (h *Handlers) CreateIncomingHook(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	if !h.callerCanManage(r, ch) {
+		respondError(w, http.StatusForbidden, "only the manager, the PO or the channel's creator can add incoming webhooks")
+		return
+	}
+	var req struct {
+		Name  string            `json:"name"`
+		Users map[string]string `json:"users"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Integration"
+	}
+	users := make(map[string]string, len(req.Users))
+	for account, handle := range req.Users {
+		ids, ok := h.resolveMention(handle)
+		if !ok || len(ids) != 1 {
+			respondError(w, http.StatusBadRequest, "users: unknown agent or role for "+account+": "+handle)
+			return
+		}
+		users[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(account), "@"))] = ids[0]
+	}
+
+	token, err := webhooks.NewSecret()
+	if err != nil {
+		log.Printf("handler: incoming webhook token: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create incoming webhook")
+		return
+	}
+
+	author := getAuthor(r)
+	hook := &models.IncomingHook{
+		ChannelID: ch.ID,
+		Name:      name,
+		Token:     token,
+		Users:     users,
+		CreatedBy: author,
+	}
+	if err := h.Store.CreateIncomingHook(r.Context(), hook); err != nil {
+		log.Printf("handler: create incoming webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create incoming webhook")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "hook.create",
+		Details: map[string]any{
+			"hook_id":      hook.ID.Hex(),
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"name":         hook.Name,
+		},
+	})
+
+	respondJSON(w, http.StatusCreated, hookResponse{IncomingHook: hook, URL: "/hooks/" + token})
+}
+
+// ListIncomingHooks handles GET /api/channels/{id}/hooks.
+// Lists the channel's incoming webhooks, oldest first, without tokens.
+func (h *Handlers) ListIncomingHooks(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	if !h.callerCanManage(r, ch) {
+		respondError(w, http.StatusForbidden, "only the manager, the PO or the channel's creator can list incoming webhooks")
+		return
+	}
+	hooks, err := h.Store.ListIncomingHooks(r.Context(), ch.ID)
+	if err != nil {
+		log.Printf("handler: list incoming webhooks: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list incoming webhooks")
+		return
+	}
+	for i := range hooks {
+		hooks[i].Token = ""
+	}
+	respondJSON(w, http.StatusOK, map[string]any{"hooks": hooks})
+}
+
+// DeleteIncomingHook handles DELETE /api/channels/{id}/hooks/{hookId}.
+// The hook's URL stops working immediately.
+func (h *Handlers) DeleteIncomingHook(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	if !h.callerCanManage(r, ch) {
+		respondError(w, http.StatusForbidden, "only the manager, the PO or the channel's creator can delete incoming webhooks")
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["hookId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid hook ID")
+		return
+	}
+	if err := h.Store.DeleteIncomingHook(r.Context(), ch.ID, id); err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "incoming webhook not found")
+			return
+		}
+		log.Printf("handler: delete incoming webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to delete incoming webhook")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  getAuthor(r),
+		Action: "hook.delete",
+		Details: map[string]any{
+			"hook_id":      id.Hex(),
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+		},
+	})
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// IncomingHook handles POST /hooks/{token}.
+// Posts a GitHub event (pull_request, push or check_run), a JUnit XML report
+// or a generic {"text", "mentions"} body to the hook's channel as a message
+// from the hook, with author role "integration" and source "github", "ci"
+// or "generic". The token in the path is the only credential. Payloads that
+// are understood but not worth a message, such as GitHub pings, are
+// answered 202 with the reason.
+func (h *Handlers) IncomingHook(w http.ResponseWriter, r *http.Request) {
+	hook, err := h.Store.GetIncomingHookByToken(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "unknown incoming webhook")
+			return
+		}
+		log.Printf("handler: get incoming webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve incoming webhook")
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHookBody))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	post, err := integrations.Parse(integrations.Request{Header: r.Header, Query: r.URL.Query(), Body: body}, hook.Users)
+	if err != nil {
+		var ignored *integrations.Ignored
+		if errors.As(err, &ignored) {
+			respondJSON(w, http.StatusAccepted, map[string]string{"status": "ignored", "reason": ignored.Reason})
+			return
+		}
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ch, err := h.Store.GetChannelByID(r.Context(), hook.ChannelID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusGone, "the incoming webhook's channel no longer exists")
+			return
+		}
+		log.Printf("handler: incoming webhook: resolve channel: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to resolve channel")
+		return
+	}
+	if err := h.Store.TouchIncomingHook(r.Context(), hook.ID); err != nil {
+		log.Printf("handler: touch incoming webhook: %v", err)
+	}
+
+	req := &messageRequest{Content: post.Text, Mentions: post.Mentions, source: post.Source}
+	ctx := context.WithValue(r.Context(), authorKey, integrationRole)
+	ctx = context.WithValue(ctx, authorInfoKey, &models.AgentInfo{ID: integrationRole, Name: hook.Name, Role: integrationRole})
+	h.createMessage(w, r.WithContext(ctx), ch, req, nil)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// legacyMentionNames are the role handles that are always accepted as
+// mentions, even when no agent registry is loaded.
+var legacyMentionNames = []string{"po", "dev", "cq", "qa", "ops", "manager", "human"}
+
+// messageRequest is the unified request body for PostMessage and PostMessageByName.
+// Agents use several names for the same thing, so the aliases are merged:
+//
+//   - content / body:                 the message text
+//   - thread_id / reply_to / in_reply_to: the message being replied to, by ID
+//     or by short ref ("#42"; see shortRef)
+//   - mentions:                       explicit mentions, merged with @mentions parsed from the text
+type messageRequest struct {
+	Channel   string   `json:"channel"`
+	Content   string   `json:"content"`
+	Body      string   `json:"body"`
+	ThreadID  string   `json:"thread_id"`
+	ReplyTo   string   `json:"reply_to"`
+	InReplyTo string   `json:"in_reply_to"`
+	Mentions  []string `json:"mentions"`
+
+	source string // Message.Source, for messages relayed from elsewhere
+}
+
+// messageRequestFields is the set of JSON fields messageRequest understands.
+var messageRequestFields = map[string]bool{
+	"channel":     true,
+	"content":     true,
+	"body":        true,
+	"thread_id":   true,
+	"reply_to":    true,
+	"in_reply_to": true,
+	"mentions":    true,
+}
+
+// postMessageResponse is the created message plus any warnings raised while
+// decoding the request.
+type postMessageResponse struct {
+	*models.Message
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// decodeMessageRequest decodes a message request body. Unknown fields and
+// conflicting aliases do not fail the request; they are returned as warnings
+// so the caller can report them back to the agent.
+func decodeMessageRequest(r *http.Request) (*messageRequest, []string, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	var req messageRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	var unknown []string
+	for key := range raw {
+		if !messageRequestFields[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("unknown field %q ignored", key))
+	}
+
+	if req.Content != "" && req.Body != "" && req.Content != req.Body {
+		warnings = append(warnings, `both "content" and "body" set; using "content"`)
+	}
+
+	target := req.replyTarget()
+	for _, ref := range []string{req.ThreadID, req.ReplyTo, req.InReplyTo} {
+		if ref != "" && ref != target {
+			warnings = append(warnings, fmt.Sprintf("conflicting reply targets; using %q", target))
+			break
+		}
+	}
+
+	return &req, warnings, nil
+}
+
+// text returns the message text, preferring "content" over "body".
+func (req *messageRequest) text() string {
+	if req.Content != "" {
+		return req.Content
+	}
+	return req.Body
+}
+
+// replyTarget returns the message ID being replied to, preferring
+// thread_id, then reply_to, then in_reply_to.
+func (req *messageRequest) replyTarget() string {
+	for _, ref := range []string{req.ThreadID, req.ReplyTo, req.InReplyTo} {
+		if ref != "" {
+			return ref
+		}
+	}
+	return ""
+}
+
+// resolveMention maps a mention handle (agent ID, display name or legacy role,
+// with or without "@") to the IDs it addresses. "everyone" expands to every
+// registered agent. ok is false if the handle is not recognised.
+func (h *Handlers) resolveMention(handle string) (ids []string, ok bool) {
+	name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	if name == "" {
+		return nil, false
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if name == "everyone" {
+		for _, agent := range h.agents {
+			ids = append(ids, agent.ID)
+		}
+		return ids, true
+	}
+	if agent, found := h.nameToAgent[name]; found {
+		return []string{agent.ID}, true
+	}
+	for _, role := range legacyMentionNames {
+		if name == role {
+			return []string{name}, true
+		}
+	}
+	return nil, false
+}
+
+// collectMentions parses @mentions from content and merges them with the
+// explicit mentions list. Explicit mentions that do not match a registered
+// agent or legacy role are dropped and reported as warnings.
+func (h *Handlers) collectMentions(content string, explicit []string) ([]string, []string) {
+	h.mu.RLock()
+	mentionRe := h.mentionRe
+	h.mu.RUnlock()
+
+	mentionSet := make(map[string]bool)
+	if mentionRe != nil {
+		for _, m := range mentionRe.FindAllStringSubmatch(content, -1) {
+			if ids, ok := h.resolveMention(m[1]); ok {
+				for _, id := range ids {
+					mentionSet[id] = true
+				}
+			} else {
+				mentionSet[strings.ToLower(m[1])] = true
+			}
+		}
+	}
+
+	var warnings []string
+	for _, handle := range explicit {
+		ids, ok := h.resolveMention(handle)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown mention %q ignored", handle))
+			continue
+		}
+		for _, id := range ids {
+			mentionSet[id] = true
+		}
+	}
+
+	mentions := make([]string, 0, len(mentionSet))
+	for m := range mentionSet {
+		mentions = append(mentions, m)
+	}
+	sort.Strings(mentions)
+	return mentions, warnings
+}
+
+// createMessage validates a decoded request, stores the message in ch, writes
+// the audit entry, broadcasts it, and responds with the created message.
+func (h *Handlers) createMessage(w http.ResponseWriter, r *http.Request, ch *models.Channel, req *messageRequest, warnings []string) {
+	if ch.Archived {
+		respondError(w, http.StatusConflict, "channel is archived: "+ch.Name)
+		return
+	}
+
+	content := req.text()
+	if strings.TrimSpace(content) == "" {
+		respondError(w, http.StatusBadRequest, "message content is required (use \"content\" or \"body\" field)")
+		return
+	}
+
+	author := getAuthor(r)
+	authorInfo := getAuthorInfo(r)
+
+	mentions, mentionWarnings := h.collectMentions(content, req.Mentions)
+	warnings = append(warnings, mentionWarnings...)
+
+	msg := &models.Message{
+		ChannelID: ch.ID,
+		Author:    author,
+		Content:   content,
+		Mentions:  mentions,
+		Source:    req.source,
+	}
+
+	// Set display name and role from registry
+	if authorInfo != nil {
+		msg.AuthorName = authorInfo.Name
+		msg.AuthorRole = authorInfo.Role
+	} else if author == "manager" {
+		h.mu.RLock()
+		msg.AuthorName = h.managerName
+		h.mu.RUnlock()
+		msg.AuthorRole = "manager"
+	}
+
+	// Replies always attach to the thread root, so replying to a reply
+	// continues the same thread.
+	if ref := req.replyTarget(); ref != "" {
+		target, problem := h.findReplyTarget(r.Context(), ch, ref)
+		if target == nil {
+			respondError(w, http.StatusBadRequest, problem)
+			return
+		}
+		tid := target.ID
+		if target.ThreadID != nil {
+			tid = *target.ThreadID
+		}
+		msg.ThreadID = &tid
+	}
+
+	if err := h.Store.CreateMessage(r.Context(), msg); err != nil {
+		log.Printf("handler: create message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create message")
+		return
+	}
+
+	h.trackTicketThreads(r.Context(), ch, msg)
+
+	// Audit entry.
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "message.post",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+			"mentions":     mentions,
+		},
+	})
+
+	// Broadcast over WebSocket.
+	h.addTicketContext(r.Context(), msg)
+	h.publish(ch.ID, EventMessageCreated, msg)
+
+	respondJSON(w, http.StatusCreated, postMessageResponse{Message: msg, Warnings: warnings})
+}
+
+// findReplyTarget resolves a reply target given as a message ID or a short
+// ref in ch. On failure it returns nil and the reason, suitable for a 400.
+func (h *Handlers) findReplyTarget(ctx context.Context, ch *models.Channel, ref string) (*models.Message, string) {
+	var target *models.Message
+	var err error
+	if seq, channel, ok := parseShortRef(ref); ok {
+		if channel != "" && !strings.EqualFold(channel, ch.Name) {
+			return nil, "reply target is in a different channel"
+		}
+		target, err = h.Store.GetMessageBySeq(ctx, ch.ID, seq)
+	} else {
+		id, parseErr := primitive.ObjectIDFromHex(ref)
+		if parseErr != nil {
+			return nil, "invalid reply target: " + ref
+		}
+		target, err = h.Store.GetMessageByID(ctx, id)
+	}
+	if err != nil {
+		return nil, "reply target not found: " + ref
+	}
+	if target.ChannelID != ch.ID {
+		return nil, "reply target is in a different channel"
+	}
+	return target, ""
+}
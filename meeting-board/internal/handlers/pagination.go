@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/store"
+)
+
+// parsePage reads the before, after, since, offset and limit query parameters
+// into a store.Page. since (RFC3339) is shorthand for an "after" cursor that
+// selects everything strictly newer than the given time; an explicit cursor
+// takes precedence over it.
+func parsePage(r *http.Request, defaultLimit int64) (store.Page, error) {
+	q := r.URL.Query()
+	page := store.Page{Limit: defaultLimit}
+
+	for _, p := range []struct {
+		name string
+		dst  **store.Cursor
+	}{{"before", &page.Before}, {"after", &page.After}} {
+		if v := q.Get(p.name); v != "" {
+			c, err := store.DecodeCursor(v)
+			if err != nil {
+				return page, errors.New("invalid " + p.name + " cursor")
+			}
+			*p.dst = c
+		}
+	}
+
+	if sinceStr := q.Get("since"); sinceStr != "" && page.After == nil && page.Before == nil {
+		t, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			return page, errors.New("invalid since parameter, use RFC3339 format")
+		}
+		page.After = store.CursorAfter(t)
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		o, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err == nil && o > 0 {
+			page.Offset = o
+		}
+	}
+	page.Limit = queryLimit(r, defaultLimit)
+	return page, nil
+}
+
+// queryLimit returns the positive "limit" query parameter, or defaultLimit if
+// it is missing or invalid.
+func queryLimit(r *http.Request, defaultLimit int64) int64 {
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.ParseInt(limitStr, 10, 64)
+		if err == nil && l > 0 {
+			return l
+		}
+	}
+	return defaultLimit
+}
+
+// maxOffsetLimit caps the limit accepted by parseOffsetLimit.
+const maxOffsetLimit = 500
+
+// parseOffsetLimit reads the offset and limit query parameters of an
+// offset-paged listing. A missing limit is defaultLimit and a larger one is
+// capped at maxOffsetLimit; malformed or negative values are an error.
+func parseOffsetLimit(r *http.Request, defaultLimit int64) (offset, limit int64, err error) {
+	q := r.URL.Query()
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 64); err != nil || offset < 0 {
+			return 0, 0, errors.New("invalid offset parameter, use a non-negative integer")
+		}
+	}
+	limit = defaultLimit
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.ParseInt(v, 10, 64); err != nil || limit <= 0 {
+			return 0, 0, errors.New("invalid limit parameter, use a positive integer")
+		}
+	}
+	return offset, min(limit, maxOffsetLimit), nil
+}
+
+// respondPage writes a paginated response envelope: the items under key,
+// plus next_cursor, prev_cursor and has_more.
+func respondPage(w http.ResponseWriter, key string, items any, info store.PageInfo) {
+	respondJSON(w, http.StatusOK, pageEnvelope(key, items, info))
+}
+
+// pageEnvelope builds the body written by respondPage, for handlers that add
+// fields of their own.
+func pageEnvelope(key string, items any, info store.PageInfo) map[string]any {
+	body := map[string]any{
+		key:        items,
+		"has_more": info.HasMore,
+	}
+	if info.NextCursor != "" {
+		body["next_cursor"] = info.NextCursor
+	}
+	if info.PrevCursor != "" {
+		body["prev_cursor"] = info.PrevCursor
+	}
+	return body
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxPinsPerChannel caps how many messages a channel can have pinned.
+const MaxPinsPerChannel = 25
+
+// unpinEvent is the payload of pin.removed and the response to UnpinMessage.
+type unpinEvent struct {
+	MessageID  primitive.ObjectID `json:"message_id"`
+	UnpinnedBy string             `json:"unpinned_by"`
+}
+
+// ListPins handles GET /api/channels/{id}/pins.
+// Returns the channel's pinned messages, most recently pinned first.
+func (h *Handlers) ListPins(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+
+	pins, err := h.Store.ListPins(r.Context(), ch)
+	if err != nil {
+		log.Printf("handler: list pins: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list pins")
+		return
+	}
+	respondJSON(w, http.StatusOK, pins)
+}
+
+// PinMessage handles POST /api/channels/{id}/pins/{messageId}.
+// Pinning an already pinned message is a no-op. Fails with 409 once the
+// channel has MaxPinsPerChannel pins.
+func (h *Handlers) PinMessage(w http.ResponseWriter, r *http.Request) {
+	ch, msg := h.pinTarget(w, r)
+	if msg == nil {
+		return
+	}
+	if ch.Archived {
+		respondError(w, http.StatusConflict, "channel is archived: "+ch.Name)
+		return
+	}
+	if msg.Deleted {
+		respondError(w, http.StatusConflict, "message has been deleted")
+		return
+	}
+
+	author := getAuthor(r)
+	pin := models.Pin{MessageID: msg.ID, PinnedBy: author, PinnedAt: time.Now().UTC()}
+	added, err := h.Store.PinMessage(r.Context(), ch.ID, pin, MaxPinsPerChannel)
+	if err != nil {
+		if err == store.ErrPinLimit {
+			respondError(w, http.StatusConflict, fmt.Sprintf("channel already has %d pinned messages; unpin one first", MaxPinsPerChannel))
+			return
+		}
+		log.Printf("handler: pin message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to pin message")
+		return
+	}
+	if !added {
+		// Report the existing pin rather than the one just attempted.
+		for _, p := range ch.Pins {
+			if p.MessageID == msg.ID {
+				pin = p
+			}
+		}
+	}
+	pinned := models.PinnedMessage{Message: *msg, PinnedBy: pin.PinnedBy, PinnedAt: pin.PinnedAt}
+	if !added {
+		respondJSON(w, http.StatusOK, pinned)
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "message.pin",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+		},
+	})
+
+	h.publish(ch.ID, EventPinAdded, pinned)
+
+	respondJSON(w, http.StatusCreated, pinned)
+}
+
+// UnpinMessage handles DELETE /api/channels/{id}/pins/{messageId}.
+// The message itself need not exist any more.
+func (h *Handlers) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["messageId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid message id")
+		return
+	}
+
+	removed, err := h.Store.UnpinMessage(r.Context(), ch.ID, id)
+	if err != nil {
+		log.Printf("handler: unpin message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to unpin message")
+		return
+	}
+	if !removed {
+		respondError(w, http.StatusNotFound, "message is not pinned")
+		return
+	}
+
+	author := getAuthor(r)
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "message.unpin",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   id.Hex(),
+		},
+	})
+
+	event := unpinEvent{MessageID: id, UnpinnedBy: author}
+	h.publish(ch.ID, EventPinRemoved, event)
+
+	respondJSON(w, http.StatusOK, event)
+}
+
+// pinTarget resolves the {id} and {messageId} path variables of a pin route,
+// writing an error response and returning nils unless the message exists in
+// that channel and the caller can access it.
+func (h *Handlers) pinTarget(w http.ResponseWriter, r *http.Request) (*models.Channel, *models.Message) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return nil, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["messageId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid message id")
+		return nil, nil
+	}
+	msg, err := h.Store.GetMessageByID(r.Context(), id)
+	if err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("handler: get message: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to get message")
+		return nil, nil
+	}
+	if err != nil || msg.ChannelID != ch.ID {
+		respondError(w, http.StatusNotFound, "message not found in #"+ch.Name)
+		return nil, nil
+	}
+	return ch, msg
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+)
+
+// maxEmojiBytes bounds the length of an emoji reaction. Multi-codepoint
+// emoji (skin tones, ZWJ sequences) are well under this.
+const maxEmojiBytes = 32
+
+// reactionEvent is the payload of reaction.added and reaction.removed: the
+// updated message plus the reaction that changed and who changed it.
+type reactionEvent struct {
+	*models.Message
+	Reaction string `json:"reaction"`
+	Actor    string `json:"actor"`
+}
+
+// normalizeReaction returns the canonical form of a reaction: a named
+// reaction (case-insensitive, optionally wrapped in colons as in ":ack:") or
+// a single emoji. ok is false if the value is neither.
+func normalizeReaction(v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	name := strings.ToLower(strings.Trim(v, ":"))
+	for _, known := range models.ReactionNames {
+		if name == known {
+			return known, true
+		}
+	}
+
+	if v == "" || len(v) > maxEmojiBytes {
+		return "", false
+	}
+	for _, r := range v {
+		if r <= unicode.MaxASCII || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return "", false
+		}
+	}
+	return v, true
+}
+
+// reactionFromRequest reads the reaction from the "reaction" query parameter
+// or a JSON body {"reaction": "..."} ("emoji" is an alias), writing a 400 and
+// returning "" if it is missing or not a known reaction or emoji.
+func reactionFromRequest(w http.ResponseWriter, r *http.Request) string {
+	value := r.URL.Query().Get("reaction")
+	if value == "" {
+		var req struct {
+			Reaction string `json:"reaction"`
+			Emoji    string `json:"emoji"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return ""
+		}
+		value = req.Reaction
+		if value == "" {
+			value = req.Emoji
+		}
+	}
+
+	name, ok := normalizeReaction(value)
+	if !ok {
+		if value == "" {
+			respondError(w, http.StatusBadRequest, "reaction is required")
+		} else {
+			respondError(w, http.StatusBadRequest, "unknown reaction: use an emoji or one of "+strings.Join(models.ReactionNames, ", "))
+		}
+		return ""
+	}
+	return name
+}
+
+// AddReaction handles POST /api/messages/{id}/reactions.
+// Body: {"reaction": "ack"} or {"emoji": "👍"}. Adding a reaction the caller
+// has already added is a no-op. A reaction from a persona mentioned in the
+// message marks that mention as responded.
+func (h *Handlers) AddReaction(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	if ch.Archived {
+		respondError(w, http.StatusConflict, "channel is archived: "+ch.Name)
+		return
+	}
+	name := reactionFromRequest(w, r)
+	if name == "" {
+		return
+	}
+
+	author := getAuthor(r)
+	reaction := models.Reaction{Name: name, Actor: author, ActorRole: h.roleOf(author)}
+	updated, added, err := h.Store.AddReaction(r.Context(), msg.ID, reaction)
+	if err != nil {
+		if err == store.ErrMessageDeleted {
+			respondError(w, http.StatusConflict, "message has been deleted")
+			return
+		}
+		log.Printf("handler: add reaction: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to add reaction")
+		return
+	}
+	if !added {
+		respondJSON(w, http.StatusOK, updated)
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "reaction.add",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+			"reaction":     name,
+		},
+	})
+
+	h.publish(ch.ID, EventReactionAdded, reactionEvent{Message: updated, Reaction: name, Actor: author})
+
+	respondJSON(w, http.StatusCreated, updated)
+}
+
+// RemoveReaction handles DELETE /api/messages/{id}/reactions.
+// The reaction is given as ?reaction=... or in the body as for AddReaction.
+// Callers can only remove their own reactions; removing one that is not
+// there is a no-op.
+func (h *Handlers) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	name := reactionFromRequest(w, r)
+	if name == "" {
+		return
+	}
+
+	author := getAuthor(r)
+	updated, removed, err := h.Store.RemoveReaction(r.Context(), msg.ID, name, author)
+	if err != nil {
+		log.Printf("handler: remove reaction: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to remove reaction")
+		return
+	}
+	if !removed {
+		respondJSON(w, http.StatusOK, updated)
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "reaction.remove",
+		Details: map[string]any{
+			"channel_id":   ch.ID.Hex(),
+			"channel_name": ch.Name,
+			"message_id":   msg.ID.Hex(),
+			"reaction":     name,
+		},
+	})
+
+	h.publish(ch.ID, EventReactionRemoved, reactionEvent{Message: updated, Reaction: name, Actor: author})
+
+	respondJSON(w, http.StatusOK, updated)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// readMarkerResponse is a read marker plus the unread count it leaves.
+type readMarkerResponse struct {
+	models.ReadMarker
+	Unread int64 `json:"unread"`
+}
+
+// MarkRead handles PUT /api/channels/{id}/read.
+// Body: {"message_id": "..."} or {"seq": N} sets the caller's read marker to
+// that message; an empty body marks the whole channel read. The marker may
+// move backwards to mark messages unread again.
+func (h *Handlers) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+
+	var req struct {
+		MessageID string `json:"message_id"`
+		Seq       *int64 `json:"seq"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	lastSeq, err := h.Store.LastSeq(r.Context(), ch.ID)
+	if err != nil {
+		log.Printf("handler: last seq: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to mark channel read")
+		return
+	}
+
+	marker := &models.ReadMarker{Reader: getAuthor(r), ChannelID: ch.ID, Seq: lastSeq}
+	switch {
+	case req.MessageID != "":
+		id, err := primitive.ObjectIDFromHex(req.MessageID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid message_id")
+			return
+		}
+		msg, err := h.Store.GetMessageByID(r.Context(), id)
+		if err != nil || msg.ChannelID != ch.ID {
+			respondError(w, http.StatusNotFound, "message not found in #"+ch.Name)
+			return
+		}
+		if msg.Seq == 0 {
+			respondError(w, http.StatusBadRequest, "message predates sequence numbers; mark by seq instead")
+			return
+		}
+		marker.Seq, marker.MessageID = msg.Seq, &msg.ID
+	case req.Seq != nil:
+		if *req.Seq < 0 || *req.Seq > lastSeq {
+			respondError(w, http.StatusBadRequest, "seq must be between 0 and the channel's last_seq")
+			return
+		}
+		marker.Seq = *req.Seq
+	}
+
+	if err := h.Store.SetReadMarker(r.Context(), marker); err != nil {
+		log.Printf("handler: set read marker: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to mark channel read")
+		return
+	}
+
+	// The marker is saved; a failed count only leaves unread at 0.
+	resp := readMarkerResponse{ReadMarker: *marker}
+	position, err := h.Store.ReadPosition(r.Context(), marker.Reader, ch.ID)
+	if err == nil {
+		resp.Unread, err = h.Store.CountUnread(r.Context(), ch.ID, marker.Reader, position)
+	}
+	if err != nil {
+		log.Printf("handler: count unread: %v", err)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// listUnreadMessages implements the unread=true form of listChannelMessages:
+// the caller's unread messages from others (thread replies included, deleted
+// messages skipped) above its read position, in seq order, as {"messages",
+// "read_seq", "last_seq", "has_more"}. It does not move the read marker;
+// callers PUT last_seq to /read once they have processed the messages.
+func (h *Handlers) listUnreadMessages(w http.ResponseWriter, r *http.Request, ch *models.Channel) {
+	reader := getAuthor(r)
+	position, err := h.Store.ReadPosition(r.Context(), reader, ch.ID)
+	if err != nil {
+		log.Printf("handler: read position: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+
+	messages, hasMore, err := h.Store.ListUnread(r.Context(), ch.ID, reader, position, queryLimit(r, 50))
+	if err != nil {
+		log.Printf("handler: list unread: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+
+	lastSeq := position
+	if len(messages) > 0 {
+		lastSeq = max(lastSeq, messages[len(messages)-1].Seq)
+	}
+	h.addTicketContext(r.Context(), messagePtrs(messages)...)
+	if wantsCompact(r) {
+		h.respondCompactMessages(w, r, ch, messages, "read_seq", position, "last_seq", lastSeq, "has_more", hasMore)
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"messages": messages,
+		"read_seq": position,
+		"last_seq": lastSeq,
+		"has_more": hasMore,
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetRefMessages handles GET /api/refs/{ref}/messages.
+// Returns every message across the channels the caller can see that
+// references a ticket or PR ("STORY-042", "MNS-22", "PR #18" or "PR-18"),
+// oldest first, as a page envelope with each message's channel and
+// permalink. threads lists, with their reply statistics, the threads those
+// messages started or belong to, and ticket_thread the ticket's canonical
+// thread if it has one the caller can see. Accepts the pagination
+// parameters (default limit 100) and format=compact.
+func (h *Handlers) GetRefMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ref := store.NormalizeRef(mux.Vars(r)["ref"])
+	if ref == "" {
+		respondError(w, http.StatusBadRequest, "ref is required")
+		return
+	}
+	page, err := parsePage(r, 100)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	channels, err := h.Store.ListChannels(ctx)
+	if err != nil {
+		log.Printf("handler: ref messages: list channels: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+	names := make(map[primitive.ObjectID]string, len(channels))
+	var visible []primitive.ObjectID
+	for i := range channels {
+		if h.callerCanAccess(r, &channels[i]) {
+			names[channels[i].ID] = channels[i].Name
+			visible = append(visible, channels[i].ID)
+		}
+	}
+
+	messages, info, err := h.Store.ListRefMessages(ctx, ref, visible, page)
+	if err != nil {
+		log.Printf("handler: ref messages: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+
+	var rootIDs []primitive.ObjectID
+	seen := make(map[primitive.ObjectID]bool)
+	for _, m := range messages {
+		root := m.ID
+		if m.ThreadID != nil {
+			root = *m.ThreadID
+		}
+		if !seen[root] {
+			seen[root] = true
+			rootIDs = append(rootIDs, root)
+		}
+	}
+	threads, err := h.Store.ListThreadSummaries(ctx, rootIDs)
+	if err != nil {
+		log.Printf("handler: ref messages: thread summaries: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list messages")
+		return
+	}
+
+	h.addTicketContext(ctx, messagePtrs(messages)...)
+
+	if wantsCompact(r) {
+		t := h.newTranscript(ctx, messagePtrs(messages))
+		if len(messages) == 0 {
+			t.heading("%s", ref)
+		}
+		channel := ""
+		for i := range messages {
+			if name := names[messages[i].ChannelID]; name != channel || i == 0 {
+				channel = name
+				t.heading("%s in #%s", ref, name)
+			}
+			t.message(&messages[i])
+		}
+		t.footer("threads", len(threads), "has_more", info.HasMore, "next_cursor", info.NextCursor, "prev_cursor", info.PrevCursor)
+		t.respond(w, r)
+		return
+	}
+
+	details := make([]messageDetail, len(messages))
+	for i, m := range messages {
+		details[i] = messageDetail{
+			Message:   m,
+			Channel:   names[m.ChannelID],
+			Permalink: permalink(r, names[m.ChannelID], m.ID),
+		}
+	}
+	body := pageEnvelope("messages", details, info)
+	body["ref"] = ref
+	body["threads"] = threadDetails(threads, names)
+	tt, err := h.Store.GetTicketThread(ctx, ref)
+	switch {
+	case err == nil && names[tt.ChannelID] != "":
+		body["ticket_thread"] = tt
+	case err != nil && err != mongo.ErrNoDocuments:
+		log.Printf("handler: ref messages: ticket thread: %v", err)
+	}
+	respondJSON(w, http.StatusOK, body)
+}
+
+// threadDetail is a thread summary with its channel name.
+type threadDetail struct {
+	models.ThreadSummary
+	Channel string `json:"channel"`
+}
+
+// threadDetails attaches channel names to thread summaries.
+func threadDetails(threads []models.ThreadSummary, names map[primitive.ObjectID]string) []threadDetail {
+	details := make([]threadDetail, len(threads))
+	for i, t := range threads {
+		details[i] = threadDetail{ThreadSummary: t, Channel: names[t.ChannelID]}
+	}
+	return details
+}
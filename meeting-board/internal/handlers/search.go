@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// snippetRadius is the number of characters of context kept on each side of
+// the first match in a search snippet.
+const snippetRadius = 80
+
+// searchHit is a single search result as returned by the API.
+type searchHit struct {
+	store.SearchResult
+	Channel string `json:"channel"`
+	Snippet string `json:"snippet"`
+}
+
+// Search handles GET /api/search.
+// Query params: q (full-text query), channel (ID or name), author, mention,
+// thread (root or reply ID), from and to (RFC3339), offset and limit
+// (default 20). At least one of q or a filter is required. Text results are
+// ordered by relevance; snippets wrap matched terms in **double asterisks**.
+// Private channels the caller cannot access are never searched.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := store.SearchQuery{
+		Text:   strings.TrimSpace(q.Get("q")),
+		Author: h.agentID(q.Get("author")),
+		Limit:  20,
+	}
+	if m := q.Get("mention"); m != "" {
+		query.Mention = h.agentID(m)
+	}
+
+	channels, err := h.Store.ListChannels(r.Context())
+	if err != nil {
+		log.Printf("handler: search channels: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to search")
+		return
+	}
+	names := make(map[primitive.ObjectID]string, len(channels))
+	for _, ch := range channels {
+		if h.callerCanAccess(r, &ch) {
+			names[ch.ID] = ch.Name
+			query.ChannelIDs = append(query.ChannelIDs, ch.ID)
+		}
+	}
+
+	if ref := q.Get("channel"); ref != "" {
+		ch := h.channelFromRef(w, r, ref)
+		if ch == nil {
+			return
+		}
+		query.ChannelIDs = []primitive.ObjectID{ch.ID}
+	}
+
+	if threadStr := q.Get("thread"); threadStr != "" {
+		tid, err := primitive.ObjectIDFromHex(threadStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid thread id")
+			return
+		}
+		if msg, err := h.Store.GetMessageByID(r.Context(), tid); err == nil && msg.ThreadID != nil {
+			tid = *msg.ThreadID
+		}
+		query.ThreadID = &tid
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid "+p.name+" parameter, use RFC3339 format")
+				return
+			}
+			*p.dst = &t
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		o, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err == nil && o > 0 {
+			query.Offset = o
+		}
+	}
+	if limitStr := q.Get("limit"); limitStr != "" {
+		l, err := strconv.ParseInt(limitStr, 10, 64)
+		if err == nil && l > 0 {
+			query.Limit = l
+		}
+	}
+
+	if query.Text == "" && query.Author == "" && query.Mention == "" && query.ThreadID == nil &&
+		query.From == nil && query.To == nil && q.Get("channel") == "" {
+		respondError(w, http.StatusBadRequest, "q or at least one filter is required")
+		return
+	}
+
+	// A caller who can see no channel finds nothing; an empty ChannelIDs
+	// would search every channel.
+	results := []store.SearchResult{}
+	if len(query.ChannelIDs) > 0 {
+		results, err = h.Store.SearchMessages(r.Context(), query)
+		if err != nil {
+			log.Printf("handler: search: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to search")
+			return
+		}
+	}
+
+	terms := searchTerms(query.Text)
+	hits := make([]searchHit, len(results))
+	for i, res := range results {
+		hits[i] = searchHit{
+			SearchResult: res,
+			Channel:      names[res.ChannelID],
+			Snippet:      snippet(res.Message, terms),
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"query":   query.Text,
+		"results": hits,
+		"offset":  query.Offset,
+		"limit":   query.Limit,
+	})
+}
+
+// searchTerms splits a text query into lower-cased terms for highlighting,
+// ignoring quotes and negated terms.
+func searchTerms(text string) []string {
+	var terms []string
+	for _, f := range strings.Fields(text) {
+		if strings.HasPrefix(f, "-") {
+			continue
+		}
+		f = strings.ToLower(strings.Trim(f, `"'`))
+		if f != "" {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
+
+// snippet returns a window of the message content around the first matched
+// term, with every matched term wrapped in ** markers. Without a match the
+// start of the content is used.
+func snippet(msg models.Message, terms []string) string {
+	content := []rune(msg.Content)
+	lower := []rune(strings.ToLower(msg.Content))
+	if len(lower) != len(content) {
+		// Lower-casing changed the length; match case-sensitively instead.
+		lower = content
+	}
+
+	start := -1
+	for _, t := range terms {
+		if i := indexRunes(lower, []rune(t), 0); i >= 0 && (start < 0 || i < start) {
+			start = i
+		}
+	}
+
+	from, to := 0, min(len(content), 2*snippetRadius)
+	if start >= 0 {
+		from = max(0, start-snippetRadius)
+		to = min(len(content), start+snippetRadius)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for i := from; i < to; {
+		matched := 0
+		for _, t := range terms {
+			tr := []rune(t)
+			if len(tr) > matched && hasPrefixAt(lower[:to], tr, i) && atWordStart(lower, i) {
+				matched = len(tr)
+			}
+		}
+		if matched > 0 {
+			end := min(i+matched, to)
+			b.WriteString("**" + string(content[i:end]) + "**")
+			i = end
+			continue
+		}
+		b.WriteRune(content[i])
+		i++
+	}
+	if to < len(content) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// indexRunes returns the index of needle in haystack at or after from, or -1.
+func indexRunes(haystack, needle []rune, from int) int {
+	if len(needle) == 0 {
+		return -1
+	}
+	for i := from; i+len(needle) <= len(haystack); i++ {
+		if hasPrefixAt(haystack, needle, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasPrefixAt reports whether needle occurs in haystack at position i.
+func hasPrefixAt(haystack, needle []rune, i int) bool {
+	return i+len(needle) <= len(haystack) && string(haystack[i:i+len(needle)]) == string(needle)
+}
+
+// atWordStart reports whether position i in s begins a word.
+func atWordStart(s []rune, i int) bool {
+	return i == 0 || !(unicode.IsLetter(s[i-1]) || unicode.IsDigit(s[i-1]))
+}
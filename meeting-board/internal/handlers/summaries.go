@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/summary"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxSummaryMessages caps how many messages go into one summary. Longer
+// conversations are summarized from their newest messages (plus the root,
+// for threads).
+const maxSummaryMessages = 500
+
+// summarizer returns the configured Summarizer, or the extractive default.
+func (h *Handlers) summarizer() summary.Summarizer {
+	if h.Summarizer != nil {
+		return h.Summarizer
+	}
+	return summary.Extractive{}
+}
+
+// GetThreadSummary handles GET /api/threads/{id}/summary.
+// Summarizes the thread containing message {id} (root or reply). Deleted
+// messages are left out.
+func (h *Handlers) GetThreadSummary(w http.ResponseWriter, r *http.Request) {
+	msg, ch := h.messageFromRequest(w, r)
+	if msg == nil {
+		return
+	}
+	root := msg
+	if msg.ThreadID != nil {
+		var err error
+		if root, err = h.Store.GetMessageByID(r.Context(), *msg.ThreadID); err != nil {
+			log.Printf("handler: summary: get thread root: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to summarize thread")
+			return
+		}
+	}
+
+	replies, err := h.Store.ListThreadReplies(r.Context(), root.ID, 0, 0)
+	if err != nil {
+		log.Printf("handler: summary: list thread replies: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to summarize thread")
+		return
+	}
+	var msgs []models.Message
+	for _, m := range append([]models.Message{*root}, replies...) {
+		if !m.Deleted {
+			msgs = append(msgs, m)
+		}
+	}
+	if len(msgs) > maxSummaryMessages {
+		msgs = append(msgs[:1], msgs[len(msgs)-maxSummaryMessages+1:]...)
+	}
+
+	conv := summary.Conversation{
+		Title:    "Thread " + shortRef(ch.Name, root.Seq, root.ID),
+		Messages: msgs,
+	}
+	h.respondSummary(w, r, ch, "thread:"+root.ID.Hex(), conv)
+}
+
+// GetChannelSummary handles GET /api/channels/{id}/summary.
+// Summarizes the channel's messages (thread replies included, deleted
+// messages left out) since the RFC3339 time in since, by default the last
+// 24 hours rounded down to the hour so that repeated calls share a cache
+// entry.
+func (h *Handlers) GetChannelSummary(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+
+	since := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Hour)
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid since parameter, use RFC3339 format")
+			return
+		}
+		since = t.UTC()
+	}
+
+	msgs, _, err := h.Store.ListMessagesSince(r.Context(), ch.ID, since, maxSummaryMessages)
+	if err != nil {
+		log.Printf("handler: summary: list messages: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to summarize channel")
+		return
+	}
+
+	conv := summary.Conversation{
+		Title:    "#" + ch.Name + " since " + since.Format("2006-01-02 15:04") + " UTC",
+		Messages: msgs,
+	}
+	h.respondSummary(w, r, ch, "channel:"+ch.ID.Hex()+":"+strconv.FormatInt(since.Unix(), 10), conv)
+}
+
+// respondSummary responds with the cached summary for scope if it still
+// covers the same messages as conv, in the same revisions, and otherwise
+// summarizes conv and caches the result. An empty conversation is not
+// summarized.
+func (h *Handlers) respondSummary(w http.ResponseWriter, r *http.Request, ch *models.Channel, scope string, conv summary.Conversation) {
+	ctx := r.Context()
+	sum := &models.ConversationSummary{
+		Scope:        scope,
+		ChannelID:    ch.ID,
+		MessageCount: int64(len(conv.Messages)),
+	}
+	for _, m := range conv.Messages {
+		sum.LastSeq = max(sum.LastSeq, m.Seq)
+		if m.EditedAt != nil && (sum.LastEditedAt == nil || m.EditedAt.After(*sum.LastEditedAt)) {
+			sum.LastEditedAt = m.EditedAt
+		}
+	}
+
+	cached, err := h.Store.GetSummary(ctx, scope)
+	switch {
+	case err == nil && cached.LastSeq == sum.LastSeq && cached.MessageCount == sum.MessageCount && sameTime(cached.LastEditedAt, sum.LastEditedAt):
+		sum = cached
+		sum.Cached = true
+	case err != nil && err != mongo.ErrNoDocuments:
+		log.Printf("handler: summary: get cached: %v", err)
+		fallthrough
+	default:
+		res, err := h.summarizer().Summarize(ctx, conv)
+		if err != nil {
+			log.Printf("handler: summarize: %v", err)
+			respondError(w, http.StatusBadGateway, "failed to summarize")
+			return
+		}
+		sum.Text, sum.Engine = res.Text, res.Engine
+		sum.GeneratedAt = time.Now().UTC()
+		if len(conv.Messages) > 0 {
+			if err := h.Store.SaveSummary(ctx, sum); err != nil {
+				log.Printf("handler: summary: save: %v", err)
+			}
+		}
+	}
+
+	if wantsCompact(r) {
+		t := &transcript{}
+		t.heading("%s", conv.Title)
+		t.b.WriteString(sum.Text + "\n")
+		t.footer("messages", sum.MessageCount, "last_seq", sum.LastSeq, "engine", sum.Engine, "cached", sum.Cached)
+		t.respond(w, r)
+		return
+	}
+	respondJSON(w, http.StatusOK, sum)
+}
+
+// sameTime reports whether a and b are both unset or the same instant.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
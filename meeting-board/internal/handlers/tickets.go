@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/planning"
+	"github.com/devteam/meeting-board/internal/store"
+)
+
+// trackTicketThreads records a newly posted message against the canonical
+// threads of the tickets it references when ticket threads are enabled,
+// linking it to threads that started elsewhere. Private channels take no
+// part, since their discussions would be synced to the planning board.
+func (h *Handlers) trackTicketThreads(ctx context.Context, ch *models.Channel, msg *models.Message) {
+	if !h.TicketThreads || ch.IsPrivate() {
+		return
+	}
+	if _, err := h.Store.TrackTicketThreads(ctx, msg); err != nil {
+		log.Printf("handler: track ticket threads: %v", err)
+	}
+}
+
+// addTicketContext fills in TicketContext on msgs for every ticket they
+// reference that the planning board knows, flagging sentences whose claims
+// the ticket contradicts. PR references are skipped. Failed lookups are
+// logged and left out, so an unavailable planning board never fails a
+// response. Without a planning board this does nothing.
+func (h *Handlers) addTicketContext(ctx context.Context, msgs ...*models.Message) {
+	if h.PlanningBoard == nil {
+		return
+	}
+
+	tickets := make(map[string]*planning.Ticket)
+	for _, m := range msgs {
+		if m.Deleted || len(m.Refs) == 0 {
+			continue
+		}
+		m.TicketContext = nil
+		var sentences []string
+		for _, ref := range m.Refs {
+			if !store.IsTicketRef(ref) {
+				continue
+			}
+			t, looked := tickets[ref]
+			if !looked {
+				var err error
+				t, err = h.PlanningBoard.GetTicket(ctx, ref)
+				if err != nil && err != planning.ErrNotFound {
+					log.Printf("handler: ticket context: %v", err)
+				}
+				tickets[ref] = t
+			}
+			if t == nil {
+				continue
+			}
+
+			tc := models.TicketContext{Ref: ref, Title: t.Title, Status: t.Status, Assignee: t.Assignee}
+			if sentences == nil {
+				sentences = planning.Sentences(m.Content)
+			}
+			for _, s := range sentences {
+				if slices.Contains(h.Store.ExtractRefs(s), ref) {
+					if tc.Warning = planning.Contradiction(s, t); tc.Warning != "" {
+						break
+					}
+				}
+			}
+			m.TicketContext = append(m.TicketContext, tc)
+		}
+	}
+}
+
+// ticketSummary renders a message's ticket context for a compact transcript
+// as "{MNS-22 in-qa}", or "{MNS-22 in-qa: says done, ticket still in-qa}"
+// when the message contradicts the ticket.
+func ticketSummary(contexts []models.TicketContext) string {
+	var parts []string
+	for _, tc := range contexts {
+		part := "{" + tc.Ref + " " + tc.Status
+		if tc.Warning != "" {
+			part += ": " + tc.Warning
+		}
+		parts = append(parts, part+"}")
+	}
+	return strings.Join(parts, " ")
+}
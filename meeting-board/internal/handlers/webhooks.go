@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"github.com/devteam/meeting-board/internal/webhooks"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// webhookManager checks that webhooks are enabled and that the caller is the
+// manager, who alone may see every channel's events. It responds with an
+// error and returns false otherwise.
+func (h *Handlers) webhookManager(w http.ResponseWriter, r *http.Request) bool {
+	if h.Webhooks == nil {
+		respondError(w, http.StatusNotFound, "webhooks are disabled")
+		return false
+	}
+	if getAuthor(r) != "manager" {
+		respondError(w, http.StatusForbidden, "only the manager can manage webhooks")
+		return false
+	}
+	return true
+}
+
+// CreateWebhook handles POST /api/webhooks.
+// Body: {"url", "events", "channels", "mentions", "secret"}. events are
+// event types (see EventTypes), channels are channel names or IDs, and
+// mentions are agents or roles a message event must mention; each is
+// optional and matches everything when empty. secret signs deliveries and
+// is generated if omitted. The response is the only place the secret is
+// shown.
+func (h *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.webhookManager(w, r) {
+		return
+	}
+	var req struct {
+		URL      string   `json:"url"`
+		Events   []string `json:"events"`
+		Channels []string `json:"channels"`
+		Mentions []string `json:"mentions"`
+		Secret   string   `json:"secret"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		respondError(w, http.StatusBadRequest, "url must be an absolute http or https URL")
+		return
+	}
+	events := []string{}
+	for _, e := range req.Events {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if !slices.Contains(EventTypes, e) {
+			respondError(w, http.StatusBadRequest, "unknown event type: "+e+"; use one of "+strings.Join(EventTypes, ", "))
+			return
+		}
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+	channelIDs := []primitive.ObjectID{}
+	for _, ref := range req.Channels {
+		ch := h.channelFromRef(w, r, ref)
+		if ch == nil {
+			return
+		}
+		if !slices.Contains(channelIDs, ch.ID) {
+			channelIDs = append(channelIDs, ch.ID)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = webhooks.NewSecret(); err != nil {
+			log.Printf("handler: webhook secret: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to create webhook")
+			return
+		}
+	}
+
+	author := getAuthor(r)
+	hook := &models.Webhook{
+		URL:        u.String(),
+		Secret:     secret,
+		Events:     events,
+		ChannelIDs: channelIDs,
+		Mentions:   h.resolveMembers(req.Mentions),
+		CreatedBy:  author,
+	}
+	if err := h.Store.CreateWebhook(r.Context(), hook); err != nil {
+		log.Printf("handler: create webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  author,
+		Action: "webhook.create",
+		Details: map[string]any{
+			"webhook_id": hook.ID.Hex(),
+			"url":        hook.URL,
+			"events":     hook.Events,
+			"channels":   len(hook.ChannelIDs),
+			"mentions":   hook.Mentions,
+		},
+	})
+
+	respondJSON(w, http.StatusCreated, hook)
+}
+
+// ListWebhooks handles GET /api/webhooks.
+// Lists every webhook, oldest first, without secrets.
+func (h *Handlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if !h.webhookManager(w, r) {
+		return
+	}
+	hooks, err := h.Store.ListWebhooks(r.Context())
+	if err != nil {
+		log.Printf("handler: list webhooks: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list webhooks")
+		return
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	respondJSON(w, http.StatusOK, map[string]any{"webhooks": hooks})
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/{id}.
+// Pending deliveries to the webhook are dead-lettered.
+func (h *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.webhookManager(w, r) {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+	if err := h.Store.DeleteWebhook(r.Context(), id); err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "webhook not found")
+			return
+		}
+		log.Printf("handler: delete webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:   getAuthor(r),
+		Action:  "webhook.delete",
+		Details: map[string]any{"webhook_id": id.Hex()},
+	})
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// ListDeliveries handles GET /api/webhooks/deliveries.
+// Returns the delivery log, newest first (paginated, default limit 50).
+// Query params: webhook (ID) and status ("pending", "delivered", or "dead"
+// for the dead-letter list).
+func (h *Handlers) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !h.webhookManager(w, r) {
+		return
+	}
+	q := r.URL.Query()
+	var filter store.DeliveryFilter
+	if v := q.Get("webhook"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid webhook ID")
+			return
+		}
+		filter.WebhookID = &id
+	}
+	switch status := q.Get("status"); status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead:
+		filter.Status = status
+	default:
+		respondError(w, http.StatusBadRequest, "status must be \"pending\", \"delivered\" or \"dead\"")
+		return
+	}
+	page, err := parsePage(r, 50)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, info, err := h.Store.ListDeliveries(r.Context(), filter, page)
+	if err != nil {
+		log.Printf("handler: list deliveries: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to list deliveries")
+		return
+	}
+	respondPage(w, "deliveries", deliveries, info)
+}
+
+// RedeliverWebhook handles POST /api/webhooks/deliveries/{id}/redeliver.
+// Queues a delivery, typically a dead-lettered one, to be sent again with a
+// fresh set of attempts. The payload and its signature are unchanged.
+func (h *Handlers) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.webhookManager(w, r) {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid delivery ID")
+		return
+	}
+	existing, err := h.Store.GetDelivery(r.Context(), id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusNotFound, "delivery not found")
+			return
+		}
+		log.Printf("handler: get delivery: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to redeliver")
+		return
+	}
+	if _, err := h.Store.GetWebhook(r.Context(), existing.WebhookID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			respondError(w, http.StatusConflict, "the delivery's webhook has been deleted")
+			return
+		}
+		log.Printf("handler: get webhook: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to redeliver")
+		return
+	}
+
+	d, err := h.Store.ResetDelivery(r.Context(), id)
+	if err != nil {
+		log.Printf("handler: redeliver: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to redeliver")
+		return
+	}
+	h.Webhooks.Retry()
+
+	h.Store.CreateAuditEntry(r.Context(), &models.AuditEntry{
+		Actor:  getAuthor(r),
+		Action: "webhook.redeliver",
+		Details: map[string]any{
+			"delivery_id":     id.Hex(),
+			"webhook_id":      d.WebhookID.Hex(),
+			"previous_status": existing.Status,
+		},
+	})
+
+	respondJSON(w, http.StatusAccepted, d)
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultContextTokens is the budget of a context window request without
+// max_tokens.
+const DefaultContextTokens = 4000
+
+// contextMessageOverhead approximates the tokens each message costs beyond
+// its author and content: time, ref and separators in a compact transcript.
+const contextMessageOverhead = 8
+
+// contextBatchSize is how many messages a context window walk loads at once.
+const contextBatchSize = 100
+
+// Tokenizer estimates how many model tokens a piece of text costs.
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// CharTokenizer estimates tokens from length alone: one token per
+// CharsPerToken characters, rounded up. Zero means 4, a fair average for
+// English text and code.
+type CharTokenizer struct {
+	CharsPerToken int
+}
+
+// CountTokens implements Tokenizer.
+func (t CharTokenizer) CountTokens(text string) int {
+	per := t.CharsPerToken
+	if per <= 0 {
+		per = 4
+	}
+	n := utf8.RuneCountInString(text)
+	return (n + per - 1) / per
+}
+
+// tokenizer returns the configured Tokenizer, or the CharTokenizer default.
+func (h *Handlers) tokenizer() Tokenizer {
+	if h.Tokenizer != nil {
+		return h.Tokenizer
+	}
+	return CharTokenizer{}
+}
+
+// messageTokens estimates what m costs in a model context.
+func (h *Handlers) messageTokens(m *models.Message) int {
+	label := authorLabel(m.Author, m.AuthorName, m.AuthorRole)
+	return h.tokenizer().CountTokens(label+": "+m.Content) + contextMessageOverhead
+}
+
+// contextWindow is the response to GetContext.
+type contextWindow struct {
+	Channel   string                 `json:"channel"`
+	MaxTokens int                    `json:"max_tokens"`
+	Tokens    int                    `json:"tokens"`
+	Pinned    []models.PinnedMessage `json:"pinned"`
+	Mentions  []models.Message       `json:"mentions"`
+	Messages  []models.Message       `json:"messages"`
+	Omitted   int64                  `json:"omitted"`
+}
+
+// GetContext handles GET /api/channels/{id}/context.
+// Returns as much recent channel history as fits a token budget, for agents
+// that would otherwise have to guess a limit. The channel's pinned messages
+// and the caller's unanswered mentions in the channel are always included
+// and counted first, even if they alone exceed the budget. History (thread
+// replies included, deleted messages skipped) is then added newest first
+// until the next message would not fit, and returned oldest first. omitted
+// counts the older messages left out.
+//
+// Query params: max_tokens (default DefaultContextTokens), agent/persona/role
+// to select whose mentions to include as for GetMentions, and format=compact.
+func (h *Handlers) GetContext(w http.ResponseWriter, r *http.Request) {
+	ch := h.channelFromRequest(w, r)
+	if ch == nil {
+		return
+	}
+	ctx := r.Context()
+
+	budget := DefaultContextTokens
+	if v := r.URL.Query().Get("max_tokens"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondError(w, http.StatusBadRequest, "invalid max_tokens parameter, use a positive integer")
+			return
+		}
+		budget = n
+	}
+
+	window := contextWindow{Channel: ch.Name, MaxTokens: budget, Messages: []models.Message{}}
+	included := make(map[primitive.ObjectID]bool)
+
+	pinned, err := h.Store.ListPins(ctx, ch)
+	if err != nil {
+		log.Printf("handler: context: list pins: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build context")
+		return
+	}
+	window.Pinned = pinned
+	for i := range pinned {
+		included[pinned[i].ID] = true
+		window.Tokens += h.messageTokens(&pinned[i].Message)
+	}
+
+	responded := false
+	mentions, _, err := h.Store.ListMentions(ctx, store.MentionFilter{
+		Recipients: h.mentionRecipients(r),
+		Responded:  &responded,
+		ChannelID:  &ch.ID,
+	}, store.Page{})
+	if err != nil {
+		log.Printf("handler: context: list mentions: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build context")
+		return
+	}
+	var mentionIDs []primitive.ObjectID
+	for _, m := range mentions {
+		if !included[m.MessageID] {
+			included[m.MessageID] = true
+			mentionIDs = append(mentionIDs, m.MessageID)
+		}
+	}
+	mentioned, err := h.Store.GetMessagesByIDs(ctx, mentionIDs)
+	if err != nil {
+		log.Printf("handler: context: get mentioned messages: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build context")
+		return
+	}
+	window.Mentions = []models.Message{}
+	for i := range mentioned {
+		if !mentioned[i].Deleted {
+			window.Mentions = append(window.Mentions, mentioned[i])
+			window.Tokens += h.messageTokens(&mentioned[i])
+		}
+	}
+
+	// Walk back from the newest message until the budget runs out.
+	var recent []models.Message
+	cutoff := int64(math.MaxInt64)
+walk:
+	for {
+		batch, err := h.Store.RecentMessages(ctx, ch.ID, cutoff, contextBatchSize)
+		if err != nil {
+			log.Printf("handler: context: recent messages: %v", err)
+			respondError(w, http.StatusInternalServerError, "failed to build context")
+			return
+		}
+		for i := range batch {
+			m := &batch[i]
+			if !included[m.ID] {
+				cost := h.messageTokens(m)
+				if window.Tokens+cost > budget {
+					break walk
+				}
+				window.Tokens += cost
+				recent = append(recent, *m)
+			}
+			cutoff = m.Seq
+		}
+		if len(batch) < contextBatchSize {
+			break
+		}
+	}
+	for i := len(recent) - 1; i >= 0; i-- {
+		window.Messages = append(window.Messages, recent[i])
+	}
+
+	exclude := make([]primitive.ObjectID, 0, len(included))
+	for id := range included {
+		exclude = append(exclude, id)
+	}
+	window.Omitted, err = h.Store.CountMessagesBefore(ctx, ch.ID, cutoff, exclude)
+	if err != nil {
+		log.Printf("handler: context: count omitted: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to build context")
+		return
+	}
+
+	msgs := make([]*models.Message, 0, len(window.Pinned)+len(window.Mentions)+len(window.Messages))
+	for i := range window.Pinned {
+		msgs = append(msgs, &window.Pinned[i].Message)
+	}
+	msgs = append(msgs, messagePtrs(window.Mentions)...)
+	msgs = append(msgs, messagePtrs(window.Messages)...)
+	h.addTicketContext(ctx, msgs...)
+
+	if wantsCompact(r) {
+		t := h.newTranscript(ctx, msgs)
+		if len(window.Pinned) > 0 {
+			t.heading("#%s pinned", ch.Name)
+			for i := range window.Pinned {
+				t.message(&window.Pinned[i].Message)
+			}
+		}
+		if len(window.Mentions) > 0 {
+			t.heading("#%s unanswered mentions", ch.Name)
+			for i := range window.Mentions {
+				t.message(&window.Mentions[i])
+			}
+		}
+		t.heading("#%s", ch.Name)
+		for i := range window.Messages {
+			t.message(&window.Messages[i])
+		}
+		t.footer("tokens", window.Tokens, "max_tokens", budget, "omitted", window.Omitted)
+		t.respond(w, r)
+		return
+	}
+	respondJSON(w, http.StatusOK, window)
+}
@@ -0,0 +1,276 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type ghUser struct {
+	Login string `json:"login"`
+}
+
+type ghRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type ghPullRequest struct {
+	Number             int      `json:"number"`
+	Title              string   `json:"title"`
+	HTMLURL            string   `json:"html_url"`
+	User               ghUser   `json:"user"`
+	Assignees          []ghUser `json:"assignees"`
+	RequestedReviewers []ghUser `json:"requested_reviewers"`
+	Draft              bool     `json:"draft"`
+	Merged             bool     `json:"merged"`
+	Head               struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	ChangedFiles int `json:"changed_files"`
+}
+
+type ghPullRequestEvent struct {
+	Action            string        `json:"action"`
+	PullRequest       ghPullRequest `json:"pull_request"`
+	RequestedReviewer *ghUser       `json:"requested_reviewer"`
+	Sender            ghUser        `json:"sender"`
+	Repository        ghRepository  `json:"repository"`
+}
+
+type ghPushEvent struct {
+	Ref     string `json:"ref"`
+	Created bool   `json:"created"`
+	Deleted bool   `json:"deleted"`
+	Forced  bool   `json:"forced"`
+	Compare string `json:"compare"`
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		Author  struct {
+			Name     string `json:"name"`
+			Username string `json:"username"`
+		} `json:"author"`
+	} `json:"commits"`
+	Sender     ghUser       `json:"sender"`
+	Repository ghRepository `json:"repository"`
+}
+
+type ghCheckRunEvent struct {
+	Action   string `json:"action"`
+	CheckRun struct {
+		Name       string `json:"name"`
+		Conclusion string `json:"conclusion"`
+		HTMLURL    string `json:"html_url"`
+		DetailsURL string `json:"details_url"`
+		Output     struct {
+			Title string `json:"title"`
+		} `json:"output"`
+		CheckSuite struct {
+			HeadBranch string `json:"head_branch"`
+		} `json:"check_suite"`
+		PullRequests []struct {
+			Number int `json:"number"`
+		} `json:"pull_requests"`
+	} `json:"check_run"`
+	Repository ghRepository `json:"repository"`
+}
+
+// parseGitHub renders a GitHub webhook event.
+func parseGitHub(event string, body []byte, users Users) (*Post, error) {
+	switch event {
+	case "ping":
+		return nil, &Ignored{Reason: "ping"}
+	case "pull_request":
+		var ev ghPullRequestEvent
+		if err := json.Unmarshal(body, &ev); err != nil {
+			return nil, fmt.Errorf("invalid GitHub %s payload: %v", event, err)
+		}
+		return gitHubPullRequest(&ev, users)
+	case "push":
+		var ev ghPushEvent
+		if err := json.Unmarshal(body, &ev); err != nil {
+			return nil, fmt.Errorf("invalid GitHub %s payload: %v", event, err)
+		}
+		return gitHubPush(&ev)
+	case "check_run":
+		var ev ghCheckRunEvent
+		if err := json.Unmarshal(body, &ev); err != nil {
+			return nil, fmt.Errorf("invalid GitHub %s payload: %v", event, err)
+		}
+		return gitHubCheckRun(&ev)
+	default:
+		return nil, &Ignored{Reason: "unsupported GitHub event " + event}
+	}
+}
+
+// gitHubPullRequest renders a pull request event. Pull requests that are
+// ready for review, or updated while awaiting it, mention their requested
+// reviewers (or CQ); pull requests closed without merging mention their
+// assignees and author (or DEV). Drafts and merges mention no one.
+func gitHubPullRequest(ev *ghPullRequestEvent, users Users) (*Post, error) {
+	pr := &ev.PullRequest
+	subject := fmt.Sprintf("PR #%d", pr.Number)
+	if ev.Repository.FullName != "" {
+		subject += " in " + ev.Repository.FullName
+	}
+
+	var head string
+	var m mentions
+	switch ev.Action {
+	case "opened", "reopened", "ready_for_review":
+		verb := map[string]string{"opened": "opened", "reopened": "reopened", "ready_for_review": "marked ready for review"}[ev.Action]
+		if pr.Draft {
+			verb = "opened as a draft"
+		}
+		head = fmt.Sprintf("%s %s by %s: %s", subject, verb, quiet(pr.User.Login), quiet(pr.Title))
+		if !pr.Draft {
+			m.addUsers(users, logins(pr.RequestedReviewers), reviewerRole)
+		}
+	case "synchronize":
+		if pr.Draft {
+			return nil, &Ignored{Reason: "draft pull request updated"}
+		}
+		head = fmt.Sprintf("%s updated with new commits by %s: %s", subject, quiet(ev.Sender.Login), quiet(pr.Title))
+		m.addUsers(users, logins(pr.RequestedReviewers), reviewerRole)
+	case "review_requested":
+		if ev.RequestedReviewer == nil {
+			return nil, &Ignored{Reason: "team review requested"}
+		}
+		head = fmt.Sprintf("%s review requested from %s: %s", subject, quiet(ev.RequestedReviewer.Login), quiet(pr.Title))
+		m.addUsers(users, []string{ev.RequestedReviewer.Login}, reviewerRole)
+	case "closed":
+		if pr.Merged {
+			head = fmt.Sprintf("%s merged into %s by %s: %s", subject, pr.Base.Ref, quiet(ev.Sender.Login), quiet(pr.Title))
+			return m.post(SourceGitHub, []string{head, pr.HTMLURL}), nil
+		}
+		head = fmt.Sprintf("%s closed without merging by %s: %s", subject, quiet(ev.Sender.Login), quiet(pr.Title))
+		m.addUsers(users, append(logins(pr.Assignees), pr.User.Login), fixerRole)
+	default:
+		return nil, &Ignored{Reason: "pull_request " + ev.Action}
+	}
+
+	lines := []string{head}
+	if pr.Head.Ref != "" && pr.Base.Ref != "" {
+		detail := fmt.Sprintf("%s -> %s", pr.Head.Ref, pr.Base.Ref)
+		if pr.ChangedFiles > 0 {
+			detail += fmt.Sprintf(", %s changed (+%d -%d)", plural(pr.ChangedFiles, "file"), pr.Additions, pr.Deletions)
+		}
+		lines = append(lines, detail)
+	}
+	if pr.HTMLURL != "" {
+		lines = append(lines, pr.HTMLURL)
+	}
+	return m.post(SourceGitHub, lines), nil
+}
+
+// gitHubPush renders a push of commits or a tag. Pushes mention no one.
+func gitHubPush(ev *ghPushEvent) (*Post, error) {
+	where := ""
+	if ev.Repository.FullName != "" {
+		where = " in " + ev.Repository.FullName
+	}
+	pusher := quiet(ev.Sender.Login)
+
+	if tag, ok := strings.CutPrefix(ev.Ref, "refs/tags/"); ok {
+		if ev.Deleted {
+			return nil, &Ignored{Reason: "tag deleted"}
+		}
+		return mentions(nil).post(SourceGitHub, []string{fmt.Sprintf("Tag %s pushed%s by %s", tag, where, pusher)}), nil
+	}
+	branch := strings.TrimPrefix(ev.Ref, "refs/heads/")
+	if ev.Deleted {
+		return nil, &Ignored{Reason: "branch deleted"}
+	}
+	if len(ev.Commits) == 0 {
+		if !ev.Created {
+			return nil, &Ignored{Reason: "push without commits"}
+		}
+		return mentions(nil).post(SourceGitHub, []string{fmt.Sprintf("Branch %s created%s by %s", branch, where, pusher)}), nil
+	}
+
+	head := fmt.Sprintf("%s pushed to %s%s by %s", plural(len(ev.Commits), "commit"), branch, where, pusher)
+	if ev.Forced {
+		head += " (force-pushed)"
+	}
+	lines := []string{head}
+	for i, c := range ev.Commits {
+		if i == maxListed {
+			lines = append(lines, fmt.Sprintf("- and %d more", len(ev.Commits)-maxListed))
+			break
+		}
+		author := c.Author.Username
+		if author == "" {
+			author = c.Author.Name
+		}
+		lines = append(lines, fmt.Sprintf("- %.7s %s (%s)", c.ID, quiet(clip(c.Message, 100)), quiet(author)))
+	}
+	if ev.Compare != "" {
+		lines = append(lines, ev.Compare)
+	}
+	return mentions(nil).post(SourceGitHub, lines), nil
+}
+
+// gitHubCheckRun renders a completed check run. Failures mention DEV, since
+// check runs do not say who pushed the commit; passes mention no one.
+func gitHubCheckRun(ev *ghCheckRunEvent) (*Post, error) {
+	run := &ev.CheckRun
+	if ev.Action != "completed" {
+		return nil, &Ignored{Reason: "check_run " + ev.Action}
+	}
+
+	var m mentions
+	var outcome string
+	switch run.Conclusion {
+	case "success":
+		outcome = "passed"
+	case "failure", "timed_out", "action_required", "startup_failure":
+		outcome = strings.ReplaceAll(run.Conclusion, "_", " ")
+		if run.Conclusion == "failure" {
+			outcome = "failed"
+		}
+		m.add(fixerRole)
+	default:
+		return nil, &Ignored{Reason: "check_run " + run.Conclusion}
+	}
+
+	head := fmt.Sprintf("Check %q %s", run.Name, outcome)
+	if run.CheckSuite.HeadBranch != "" {
+		head += " on " + run.CheckSuite.HeadBranch
+	}
+	if len(run.PullRequests) > 0 {
+		prs := make([]string, len(run.PullRequests))
+		for i, pr := range run.PullRequests {
+			prs[i] = fmt.Sprintf("PR #%d", pr.Number)
+		}
+		head += " (" + strings.Join(prs, ", ") + ")"
+	}
+	if ev.Repository.FullName != "" {
+		head += " in " + ev.Repository.FullName
+	}
+	if run.Output.Title != "" {
+		head += ": " + quiet(clip(run.Output.Title, 200))
+	}
+
+	lines := []string{head}
+	url := run.HTMLURL
+	if url == "" {
+		url = run.DetailsURL
+	}
+	if url != "" {
+		lines = append(lines, url)
+	}
+	return m.post(SourceGitHub, lines), nil
+}
+
+func logins(users []ghUser) []string {
+	result := make([]string, len(users))
+	for i, u := range users {
+		result[i] = u.Login
+	}
+	return result
+}
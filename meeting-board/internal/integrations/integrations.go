@@ -0,0 +1,163 @@
+// Package integrations renders payloads posted to incoming webhooks, such as
+// GitHub events, JUnit test reports and plain text, as meeting board
+// messages that mention whoever has to act on them.
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sources, as recorded in Message.Source.
+const (
+	SourceGitHub  = "github"
+	SourceCI      = "ci"
+	SourceGeneric = "generic"
+)
+
+// Roles mentioned when none of the people involved map to a board handle.
+const (
+	reviewerRole = "cq"  // reviews pull requests
+	fixerRole    = "dev" // fixes what failed
+)
+
+// maxListed caps the commits or failed tests listed in one message.
+const maxListed = 10
+
+// Request is an incoming webhook request.
+type Request struct {
+	Header http.Header
+	Query  url.Values
+	Body   []byte
+}
+
+// Post is a message rendered from an incoming payload. Mentions are board
+// handles and also appear in Text.
+type Post struct {
+	Source   string
+	Text     string
+	Mentions []string
+}
+
+// Ignored is returned for payloads that are understood but not worth a
+// message, such as GitHub pings or label changes.
+type Ignored struct {
+	Reason string
+}
+
+func (e *Ignored) Error() string {
+	return "ignored: " + e.Reason
+}
+
+// Users maps external accounts, such as GitHub logins, to board handles.
+// Keys are lower case.
+type Users map[string]string
+
+// handle returns the board handle for an external account, or "".
+func (u Users) handle(account string) string {
+	return u[strings.ToLower(account)]
+}
+
+// Parse renders an incoming webhook request. GitHub events are recognised by
+// their X-GitHub-Event header and JUnit reports by an XML body; anything
+// else must be a generic {"text", "mentions"} object, which is also what
+// Slack-style incoming webhooks send.
+func Parse(req Request, users Users) (*Post, error) {
+	if event := req.Header.Get("X-GitHub-Event"); event != "" {
+		return parseGitHub(event, req.Body, users)
+	}
+	if strings.Contains(req.Header.Get("Content-Type"), "xml") || bytes.HasPrefix(bytes.TrimSpace(req.Body), []byte("<")) {
+		return parseJUnit(req.Body, req.Query, users)
+	}
+	return parseGeneric(req.Body, users)
+}
+
+// parseGeneric renders {"text", "mentions"}. Mentions are board handles or
+// mapped external accounts.
+func parseGeneric(body []byte, users Users) (*Post, error) {
+	var in struct {
+		Text     string   `json:"text"`
+		Mentions []string `json:"mentions"`
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		return nil, fmt.Errorf("body must be a JSON object with \"text\", a JUnit XML report or a GitHub event")
+	}
+	if strings.TrimSpace(in.Text) == "" {
+		return nil, fmt.Errorf("\"text\" is required")
+	}
+
+	var m mentions
+	for _, handle := range in.Mentions {
+		handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+		if mapped := users.handle(handle); mapped != "" {
+			handle = mapped
+		}
+		m.add(handle)
+	}
+	return m.post(SourceGeneric, []string{in.Text}), nil
+}
+
+// mentions collects board handles in order, without duplicates.
+type mentions []string
+
+func (m *mentions) add(handle string) {
+	handle = strings.ToLower(handle)
+	if handle == "" {
+		return
+	}
+	for _, h := range *m {
+		if h == handle {
+			return
+		}
+	}
+	*m = append(*m, handle)
+}
+
+// addUsers adds the handles of the given accounts, or fallback if none of
+// them is mapped.
+func (m *mentions) addUsers(users Users, accounts []string, fallback string) {
+	n := len(*m)
+	for _, a := range accounts {
+		m.add(users.handle(a))
+	}
+	if len(*m) == n {
+		m.add(fallback)
+	}
+}
+
+// post joins lines into a message, ending with a "cc" line for the mentions.
+func (m mentions) post(source string, lines []string) *Post {
+	if len(m) > 0 {
+		lines = append(lines, "cc @"+strings.Join(m, " @"))
+	}
+	return &Post{Source: source, Text: strings.Join(lines, "\n"), Mentions: m}
+}
+
+// quiet keeps text from outside the board, such as a PR title, from
+// mentioning anyone: "@" is followed by a zero-width space.
+func quiet(s string) string {
+	return strings.ReplaceAll(s, "@", "@\u200b")
+}
+
+// clip returns the first line of s, at most max characters long.
+func clip(s string, max int) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) > max {
+		s = strings.TrimSpace(string([]rune(s)[:max-1])) + "…"
+	}
+	return s
+}
+
+// plural returns "1 commit" or "3 commits".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,172 @@
+package integrations
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// junitSuite is a <testsuites> or <testsuite> element. Suites may nest.
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string       `xml:"name,attr"`
+	Time    string       `xml:"time,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+	Cases   []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitResult is a report's totals and its failed or errored cases.
+type junitResult struct {
+	tests, failures, errors, skipped int
+	failed                           []string
+}
+
+// parseJUnit renders a JUnit XML test report. The query string adds what the
+// report cannot say: name (the job, default the report's name), ref (the
+// branch), pr (a pull request number), url (the CI run) and author (a board
+// handle or mapped account). Failures mention the author, or DEV.
+func parseJUnit(body []byte, query url.Values, users Users) (*Post, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("invalid JUnit report: %v", err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, fmt.Errorf("invalid JUnit report: root element is <%s>, not <testsuites> or <testsuite>", root.XMLName.Local)
+	}
+	var res junitResult
+	res.add(&root)
+
+	name := query.Get("name")
+	if name == "" {
+		name = root.Name
+	}
+	if name == "" && len(root.Suites) == 1 {
+		name = root.Suites[0].Name
+	}
+	if name == "" {
+		name = "tests"
+	}
+
+	var m mentions
+	outcome := "passed"
+	if res.failures+res.errors > 0 {
+		outcome = "failed"
+		author := strings.TrimPrefix(strings.TrimSpace(query.Get("author")), "@")
+		if mapped := users.handle(author); mapped != "" {
+			author = mapped
+		}
+		if author == "" {
+			author = fixerRole
+		}
+		m.add(author)
+	}
+
+	head := fmt.Sprintf("CI: %q %s", quiet(name), outcome)
+	if ref := query.Get("ref"); ref != "" {
+		head += " on " + quiet(ref)
+	}
+	if pr, err := strconv.Atoi(strings.TrimPrefix(query.Get("pr"), "#")); err == nil && pr > 0 {
+		head += fmt.Sprintf(" (PR #%d)", pr)
+	}
+	head += ": " + res.counts()
+	if d := duration(&root); d > 0 {
+		head += fmt.Sprintf(" in %s", d)
+	}
+
+	lines := []string{head}
+	for i, f := range res.failed {
+		if i == maxListed {
+			lines = append(lines, fmt.Sprintf("- and %d more", len(res.failed)-maxListed))
+			break
+		}
+		lines = append(lines, "- "+quiet(f))
+	}
+	if u := query.Get("url"); u != "" {
+		lines = append(lines, u)
+	}
+	return m.post(SourceCI, lines), nil
+}
+
+// add counts the cases in s and its nested suites.
+func (res *junitResult) add(s *junitSuite) {
+	for i := range s.Suites {
+		res.add(&s.Suites[i])
+	}
+	for _, c := range s.Cases {
+		res.tests++
+		name := c.Name
+		if c.ClassName != "" {
+			name = c.ClassName + "." + c.Name
+		}
+		switch {
+		case c.Failure != nil:
+			res.failures++
+			res.failed = append(res.failed, name+problem(c.Failure))
+		case c.Error != nil:
+			res.errors++
+			res.failed = append(res.failed, name+" (error)"+problem(c.Error))
+		case c.Skipped != nil:
+			res.skipped++
+		}
+	}
+}
+
+// counts summarizes the totals: "3 of 120 tests failed, 1 error, 2 skipped".
+func (res *junitResult) counts() string {
+	var parts []string
+	if res.failures > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %s failed", res.failures, plural(res.tests, "test")))
+	} else {
+		parts = append(parts, plural(res.tests, "test"))
+	}
+	if res.errors > 0 {
+		parts = append(parts, plural(res.errors, "error"))
+	}
+	if res.skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", res.skipped))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// problem returns ": " and the first line of a failure's message, or "".
+func problem(p *junitProblem) string {
+	msg := p.Message
+	if strings.TrimSpace(msg) == "" {
+		msg = p.Text
+	}
+	if msg = clip(msg, 120); msg == "" {
+		return ""
+	}
+	return ": " + msg
+}
+
+// duration returns the report's run time: the root's time attribute, or the
+// sum of its suites'.
+func duration(root *junitSuite) time.Duration {
+	secs, err := strconv.ParseFloat(strings.ReplaceAll(root.Time, ",", ""), 64)
+	if err != nil {
+		secs = 0
+		for _, s := range root.Suites {
+			if t, err := strconv.ParseFloat(strings.ReplaceAll(s.Time, ",", ""), 64); err == nil {
+				secs += t
+			}
+		}
+	}
+	return time.Duration(secs * float64(time.Second)).Round(100 * time.Millisecond)
+}
@@ -6,27 +6,155 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Channel visibility values. Channels created before visibility existed have
+// an empty value and are treated as public.
+const (
+	ChannelPublic  = "public"
+	ChannelPrivate = "private"
+)
+
 // Channel represents a communication channel on the meeting board.
 // All bot-to-bot communication flows through channels — never directly.
+// Private channels are only visible to their members, their creator and the manager.
 type Channel struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Name        string             `json:"name" bson:"name"`
 	Description string             `json:"description" bson:"description"`
+	Purpose     string             `json:"purpose,omitempty" bson:"purpose,omitempty"`
+	Topic       string             `json:"topic,omitempty" bson:"topic,omitempty"`
+	Members     []string           `json:"members,omitempty" bson:"members,omitempty"`
+	Visibility  string             `json:"visibility" bson:"visibility"`
+	CreatedBy   string             `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	Archived    bool               `json:"archived" bson:"archived"`
+	ArchivedAt  *time.Time         `json:"archived_at,omitempty" bson:"archived_at,omitempty"`
+	Retention   *RetentionPolicy   `json:"retention,omitempty" bson:"retention,omitempty"`
+	Pins        []Pin              `json:"pins,omitempty" bson:"pins,omitempty"`
 	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
 }
 
+// Pin records a message pinned to a channel, newest last.
+type Pin struct {
+	MessageID primitive.ObjectID `json:"message_id" bson:"message_id"`
+	PinnedBy  string             `json:"pinned_by" bson:"pinned_by"`
+	PinnedAt  time.Time          `json:"pinned_at" bson:"pinned_at"`
+}
+
+// PinnedMessage is a pinned message along with who pinned it and when.
+type PinnedMessage struct {
+	Message  `bson:",inline"`
+	PinnedBy string    `json:"pinned_by" bson:"pinned_by"`
+	PinnedAt time.Time `json:"pinned_at" bson:"pinned_at"`
+}
+
+// RetentionPolicy controls how long a channel's messages stay in the live
+// collection before the retention worker moves them to cold storage. A
+// top-level message expires once it is older than MaxAgeDays or falls
+// outside the newest MaxCount top-level messages; zero disables that limit.
+// Thread replies expire with their root. A channel without a policy uses the
+// server default.
+type RetentionPolicy struct {
+	KeepForever bool `json:"keep_forever,omitempty" bson:"keep_forever,omitempty"`
+	MaxAgeDays  int  `json:"max_age_days,omitempty" bson:"max_age_days,omitempty"`
+	MaxCount    int  `json:"max_count,omitempty" bson:"max_count,omitempty"`
+}
+
+// Expires reports whether the policy ever moves messages out of the channel.
+func (p RetentionPolicy) Expires() bool {
+	return !p.KeepForever && (p.MaxAgeDays > 0 || p.MaxCount > 0)
+}
+
+// IsPrivate reports whether the channel is restricted to its members.
+func (c *Channel) IsPrivate() bool {
+	return c.Visibility == ChannelPrivate
+}
+
 // Message represents a single message posted to a channel.
 // Messages may optionally belong to a thread (identified by ThreadID).
+// Seq is a per-channel, monotonically increasing sequence number assigned on
+// insert (thread replies included), so clients can detect missed messages.
+// ReactionCounts aggregates Reactions by name. Refs holds the ticket and PR
+// references found in Content (see store.NormalizeRef). Source names where a
+// message came from when it was not posted through the API, such as
+// "discord" for a human reply relayed by the bridge.
 type Message struct {
 	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
 	ChannelID  primitive.ObjectID  `json:"channel_id" bson:"channel_id"`
+	Seq        int64               `json:"seq" bson:"seq"`
 	ThreadID   *primitive.ObjectID `json:"thread_id,omitempty" bson:"thread_id,omitempty"`
 	Author     string              `json:"author" bson:"author"`
 	AuthorName string              `json:"author_name,omitempty" bson:"author_name,omitempty"`
 	AuthorRole string              `json:"author_role,omitempty" bson:"author_role,omitempty"`
 	Content    string              `json:"content" bson:"content"`
 	Mentions   []string            `json:"mentions" bson:"mentions"`
+	Refs       []string            `json:"refs,omitempty" bson:"refs"`
+	Source     string              `json:"source,omitempty" bson:"source,omitempty"`
 	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	EditedAt   *time.Time          `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+	Deleted    bool                `json:"deleted,omitempty" bson:"deleted,omitempty"`
+	DeletedAt  *time.Time          `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy  string              `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+	History    []MessageRevision   `json:"history,omitempty" bson:"history,omitempty"`
+
+	Reactions      []Reaction     `json:"reactions,omitempty" bson:"reactions,omitempty"`
+	ReactionCounts map[string]int `json:"reaction_counts,omitempty" bson:"reaction_counts,omitempty"`
+
+	// TicketThreads links a message to the canonical discussion threads of
+	// tickets it references that started elsewhere.
+	TicketThreads []TicketThreadLink `json:"ticket_threads,omitempty" bson:"ticket_threads,omitempty"`
+
+	// TicketContext is filled in on responses from the planning board; it is
+	// never stored.
+	TicketContext []TicketContext `json:"ticket_context,omitempty" bson:"-"`
+}
+
+// TicketThreadLink points from a message to the canonical thread of a ticket
+// it references.
+type TicketThreadLink struct {
+	Ref       string             `json:"ref" bson:"ref"`
+	ChannelID primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	ThreadID  primitive.ObjectID `json:"thread_id" bson:"thread_id"`
+}
+
+// TicketContext is the live planning board state of a ticket a message
+// references. Warning flags a contradiction between the message and the
+// ticket, such as "says done, ticket still in-qa".
+type TicketContext struct {
+	Ref      string `json:"ref"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	Warning  string `json:"warning,omitempty"`
+}
+
+// Reaction vocabulary. Besides these names, a reaction may be a single emoji.
+const (
+	ReactionAck     = "ack"
+	ReactionApprove = "approve"
+	ReactionReject  = "reject"
+	ReactionBlocked = "blocked"
+	ReactionDone    = "done"
+	ReactionSeen    = "seen"
+)
+
+// ReactionNames lists the named reactions, in display order.
+var ReactionNames = []string{ReactionAck, ReactionApprove, ReactionReject, ReactionBlocked, ReactionDone, ReactionSeen}
+
+// Reaction is a lightweight response to a message. Each actor may add a
+// given reaction to a message once.
+type Reaction struct {
+	Name      string    `json:"name" bson:"name"`
+	Actor     string    `json:"actor" bson:"actor"`
+	ActorRole string    `json:"actor_role,omitempty" bson:"actor_role,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// MessageRevision is a prior version of an edited or deleted message.
+// ReplacedAt and ReplacedBy record when and by whom it was superseded.
+type MessageRevision struct {
+	Content    string    `json:"content" bson:"content"`
+	Mentions   []string  `json:"mentions" bson:"mentions"`
+	ReplacedAt time.Time `json:"replaced_at" bson:"replaced_at"`
+	ReplacedBy string    `json:"replaced_by" bson:"replaced_by"`
 }
 
 // AgentInfo represents a registered agent from the agents-registry.json file.
@@ -47,3 +175,176 @@ type AuditEntry struct {
 	Details   map[string]any     `json:"details" bson:"details"`
 	Timestamp time.Time          `json:"timestamp" bson:"timestamp"`
 }
+
+// Mention is a per-recipient inbox item created for every agent mentioned in a
+// message. It stays unresponded until the recipient replies in the same
+// thread (or channel, for top-level messages) or acknowledges it explicitly.
+type Mention struct {
+	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MessageID    primitive.ObjectID  `json:"message_id" bson:"message_id"`
+	ChannelID    primitive.ObjectID  `json:"channel_id" bson:"channel_id"`
+	Seq          int64               `json:"seq,omitempty" bson:"seq,omitempty"` // seq of the message; 0 for mentions recorded before seq was copied
+	Channel      string              `json:"channel,omitempty" bson:"-"`
+	ThreadID     *primitive.ObjectID `json:"thread_id,omitempty" bson:"thread_id,omitempty"`
+	Recipient    string              `json:"recipient" bson:"recipient"`
+	Author       string              `json:"author" bson:"author"`
+	AuthorName   string              `json:"author_name,omitempty" bson:"author_name,omitempty"`
+	AuthorRole   string              `json:"author_role,omitempty" bson:"author_role,omitempty"`
+	Content      string              `json:"content" bson:"content"`
+	Responded    bool                `json:"responded" bson:"responded"`
+	RespondedAt  *time.Time          `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+	RespondedVia string              `json:"responded_via,omitempty" bson:"responded_via,omitempty"` // "reply", "ack" or "reaction"
+	ResponseID   *primitive.ObjectID `json:"response_id,omitempty" bson:"response_id,omitempty"`
+	CreatedAt    time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// ChannelStats summarises activity in a channel. ReadSeq and Unread are
+// relative to the caller: ReadSeq is the caller's read position (see
+// ReadMarker) and Unread counts undeleted messages from others above it.
+type ChannelStats struct {
+	MessageCount   int64      `json:"message_count" bson:"message_count"`
+	LastMessageAt  *time.Time `json:"last_message_at,omitempty" bson:"last_message_at,omitempty"`
+	LastAuthor     string     `json:"last_author,omitempty" bson:"last_author,omitempty"`
+	LastAuthorName string     `json:"last_author_name,omitempty" bson:"last_author_name,omitempty"`
+	LastSeq        int64      `json:"last_seq" bson:"last_seq"`
+	ReadSeq        int64      `json:"read_seq" bson:"read_seq"`
+	Unread         int64      `json:"unread" bson:"unread"`
+}
+
+// ReadMarker records how far a reader has read in a channel: messages with a
+// seq at or below Seq count as read. A reader's effective read position is
+// the later of its marker and its own latest message in the channel, since
+// posting implies having read what came before.
+type ReadMarker struct {
+	ID        primitive.ObjectID  `json:"-" bson:"_id,omitempty"`
+	Reader    string              `json:"reader" bson:"reader"`
+	ChannelID primitive.ObjectID  `json:"channel_id" bson:"channel_id"`
+	Seq       int64               `json:"seq" bson:"seq"`
+	MessageID *primitive.ObjectID `json:"message_id,omitempty" bson:"message_id,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at" bson:"updated_at"`
+
+	// Seen lists messages above Seq already shown in the reader's feed.
+	Seen []SeenMessage `json:"-" bson:"seen,omitempty"`
+}
+
+// SeenMessage is a message above a read marker that the reader has seen.
+type SeenMessage struct {
+	ID  primitive.ObjectID `bson:"id"`
+	Seq int64              `bson:"seq"`
+}
+
+// ThreadSummary is a thread root message together with statistics about its
+// replies. Participants includes the root author and everyone who replied.
+type ThreadSummary struct {
+	Message      `bson:",inline"`
+	ReplyCount   int64      `json:"reply_count" bson:"reply_count"`
+	LastReplyAt  *time.Time `json:"last_reply_at,omitempty" bson:"last_reply_at,omitempty"`
+	Participants []string   `json:"participants" bson:"participants"`
+}
+
+// ClearOperation records a channel clear. Cleared messages are moved to an
+// archive tagged with the operation's ID and can be restored until ExpiresAt.
+type ClearOperation struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ChannelID    primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	ChannelName  string             `json:"channel_name" bson:"channel_name"`
+	Actor        string             `json:"actor" bson:"actor"`
+	MessageCount int64              `json:"message_count" bson:"message_count"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	ExpiresAt    time.Time          `json:"expires_at" bson:"expires_at"`
+	RestoredAt   *time.Time         `json:"restored_at,omitempty" bson:"restored_at,omitempty"`
+	RestoredBy   string             `json:"restored_by,omitempty" bson:"restored_by,omitempty"`
+	PurgedAt     *time.Time         `json:"purged_at,omitempty" bson:"purged_at,omitempty"`
+}
+
+// ConversationSummary is a cached summary of a thread, or of a channel since
+// a point in time. It stays valid while the conversation's LastSeq and
+// MessageCount are unchanged, so any new message invalidates it.
+type ConversationSummary struct {
+	ID           primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Scope        string             `json:"scope" bson:"scope"` // "thread:<root id>" or "channel:<id>:<since unix>"
+	ChannelID    primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	Text         string             `json:"summary" bson:"text"`
+	Engine       string             `json:"engine" bson:"engine"`
+	MessageCount int64              `json:"message_count" bson:"message_count"`
+	LastSeq      int64              `json:"last_seq" bson:"last_seq"`
+	LastEditedAt *time.Time         `json:"last_edited_at,omitempty" bson:"last_edited_at,omitempty"`
+	GeneratedAt  time.Time          `json:"generated_at" bson:"generated_at"`
+	Cached       bool               `json:"cached" bson:"-"`
+}
+
+// TicketThread is the canonical discussion thread of a ticket: the thread of
+// the first public message that referenced it. LastActivityAt moves whenever
+// the thread gets a reply or the ticket is referenced elsewhere; the sync
+// worker posts what changed since SyncedThrough as a ticket comment, backing
+// off after failures until NextAttemptAt.
+type TicketThread struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Ref            string             `json:"ref" bson:"ref"`
+	ChannelID      primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	RootID         primitive.ObjectID `json:"root_id" bson:"root_id"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	LastActivityAt time.Time          `json:"last_activity_at" bson:"last_activity_at"`
+	SyncedThrough  *time.Time         `json:"synced_through,omitempty" bson:"synced_through,omitempty"`
+	Comments       int                `json:"comments" bson:"comments"`
+	Attempts       int                `json:"attempts,omitempty" bson:"attempts,omitempty"`
+	NextAttemptAt  *time.Time         `json:"next_attempt_at,omitempty" bson:"next_attempt_at,omitempty"`
+	LastError      string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+}
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook is an outbound subscription to board events. An empty Events,
+// ChannelIDs or Mentions list matches everything; Mentions only matches
+// events that carry a message. Secret signs every delivery and is only
+// shown when the webhook is created.
+type Webhook struct {
+	ID         primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	URL        string               `json:"url" bson:"url"`
+	Secret     string               `json:"secret,omitempty" bson:"secret"`
+	Events     []string             `json:"events" bson:"events"`
+	ChannelIDs []primitive.ObjectID `json:"channel_ids" bson:"channel_ids"`
+	Mentions   []string             `json:"mentions" bson:"mentions"`
+	CreatedBy  string               `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time            `json:"created_at" bson:"created_at"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook. Payload is
+// the exact body posted, so retries carry the same signature. A delivery
+// stays pending, retried with backoff from NextAttemptAt, until it succeeds
+// or runs out of attempts and is dead-lettered.
+type WebhookDelivery struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	WebhookID     primitive.ObjectID `json:"webhook_id" bson:"webhook_id"`
+	Event         string             `json:"event" bson:"event"`
+	ChannelID     primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	Payload       string             `json:"payload" bson:"payload"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastStatus    int                `json:"last_status,omitempty" bson:"last_status,omitempty"` // HTTP status of the last attempt
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty" bson:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	DeliveredAt   *time.Time         `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+}
+
+// IncomingHook is a channel's incoming webhook: payloads posted to
+// /hooks/{token} become messages in the channel, authored by Name with the
+// "integration" role. Users maps external accounts (GitHub logins, lower
+// case) to the board handles they are mentioned as. Token is the hook's only
+// credential and is only shown when the hook is created.
+type IncomingHook struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ChannelID  primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	Name       string             `json:"name" bson:"name"`
+	Token      string             `json:"token,omitempty" bson:"token"`
+	Users      map[string]string  `json:"users,omitempty" bson:"users,omitempty"`
+	CreatedBy  string             `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+}
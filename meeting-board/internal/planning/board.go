@@ -0,0 +1,210 @@
+// Package planning looks up tickets on the planning board so the meeting
+// board can show what a message's ticket references actually say.
+package planning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by Board.GetTicket for unknown tickets.
+var ErrNotFound = errors.New("ticket not found")
+
+// Ticket is the part of a planning board ticket the meeting board shows.
+type Ticket struct {
+	Number   string `json:"ticket"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// Board looks up tickets by number (e.g. "MNS-22").
+type Board interface {
+	GetTicket(ctx context.Context, number string) (*Ticket, error)
+}
+
+// Commenter posts comments on tickets.
+type Commenter interface {
+	AddComment(ctx context.Context, number, text string) error
+}
+
+// HTTPBoard is a Board and Commenter backed by the planning board's REST API.
+type HTTPBoard struct {
+	BaseURL string       // e.g. "http://project-board:3000"
+	Token   string       // planning board API token, sent as a bearer token
+	Client  *http.Client // nil means a client with a three-second timeout
+}
+
+// do sends a request for a ticket resource, with body encoded as JSON if
+// it is not nil.
+func (b HTTPBoard) do(ctx context.Context, method, number, path string, body any) (*http.Response, error) {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewReader(data)
+	}
+	u := strings.TrimRight(b.BaseURL, "/") + "/api/tickets/" + url.PathEscape(number) + path
+	req, err := http.NewRequestWithContext(ctx, method, u, payload)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if b.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.Token)
+	}
+
+	client := b.Client
+	if client == nil {
+		client = &http.Client{Timeout: 3 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("planning board: %w", err)
+	}
+	return resp, nil
+}
+
+// GetTicket implements Board using GET /api/tickets/{number}.
+func (b HTTPBoard) GetTicket(ctx context.Context, number string) (*Ticket, error) {
+	resp, err := b.do(ctx, http.MethodGet, number, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("planning board: get %s: %s", number, resp.Status)
+	}
+
+	var task struct {
+		TicketNumber string  `json:"ticketNumber"`
+		Title        string  `json:"title"`
+		Name         string  `json:"name"`
+		Status       string  `json:"status"`
+		Assignee     *string `json:"assignee"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("planning board: decode %s: %w", number, err)
+	}
+	t := &Ticket{Number: task.TicketNumber, Title: task.Title, Status: task.Status}
+	if t.Number == "" {
+		t.Number = number
+	}
+	if t.Title == "" {
+		t.Title = task.Name
+	}
+	if task.Assignee != nil {
+		t.Assignee = *task.Assignee
+	}
+	return t, nil
+}
+
+// AddComment implements Commenter using POST /api/tickets/{number}/comments.
+// The comment is posted as the user the token belongs to.
+func (b HTTPBoard) AddComment(ctx context.Context, number, text string) error {
+	resp, err := b.do(ctx, http.MethodPost, number, "/comments", map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("planning board: comment on %s: %s", number, resp.Status)
+	}
+	return nil
+}
+
+// Static is a Board that serves a fixed set of tickets keyed by number, for
+// local development and tests.
+type Static map[string]Ticket
+
+// GetTicket implements Board.
+func (s Static) GetTicket(ctx context.Context, number string) (*Ticket, error) {
+	t, ok := s[strings.ToUpper(number)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &t, nil
+}
+
+// errTTL is how long Cache remembers a failed lookup, so an unreachable
+// planning board is not asked again on every request.
+const errTTL = 30 * time.Second
+
+// maxCacheEntries bounds Cache; expired entries are dropped beyond it.
+const maxCacheEntries = 1000
+
+// Cache is a Board that remembers another Board's answers, unknown tickets
+// included, for TTL.
+type Cache struct {
+	Board Board
+	TTL   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	ticket  *Ticket
+	err     error
+	expires time.Time
+}
+
+// GetTicket implements Board.
+func (c *Cache) GetTicket(ctx context.Context, number string) (*Ticket, error) {
+	number = strings.ToUpper(number)
+	now := time.Now()
+
+	c.mu.Lock()
+	if e, ok := c.entries[number]; ok && now.Before(e.expires) {
+		c.mu.Unlock()
+		return e.ticket, e.err
+	}
+	c.mu.Unlock()
+
+	t, err := c.Board.GetTicket(ctx, number)
+	ttl := c.TTL
+	if err != nil && err != ErrNotFound {
+		ttl = min(ttl, errTTL)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+	if len(c.entries) >= maxCacheEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			c.entries = make(map[string]cacheEntry)
+		}
+	}
+	c.entries[number] = cacheEntry{ticket: t, err: err, expires: now.Add(ttl)}
+	return t, err
+}
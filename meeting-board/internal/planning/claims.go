@@ -0,0 +1,94 @@
+package planning
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Planning board statuses, in workflow order.
+const (
+	StatusBacklog    = "backlog"
+	StatusTodo       = "todo"
+	StatusInProgress = "in-progress"
+	StatusBlocked    = "blocked"
+	StatusInReview   = "in-review"
+	StatusInQA       = "in-qa"
+	StatusCompleted  = "completed"
+	StatusClosed     = "closed"
+	StatusRFP        = "rfp"
+)
+
+// progress ranks statuses by how far along the workflow a ticket is. A
+// blocked ticket counts as in progress.
+var progress = map[string]int{
+	StatusBacklog:    0,
+	StatusTodo:       1,
+	StatusInProgress: 2,
+	StatusBlocked:    2,
+	StatusInReview:   3,
+	StatusInQA:       4,
+	StatusCompleted:  5,
+	StatusClosed:     5,
+	StatusRFP:        5,
+}
+
+// claims maps phrases to the status a message using them implies, most
+// advanced first so "merged, ready for QA" reads as done.
+var claims = []struct {
+	status string
+	label  string
+	re     *regexp.Regexp
+}{
+	{StatusCompleted, "done", regexp.MustCompile(`(?i)\b(done|complete|completed|finished|merged|shipped|closed)\b`)},
+	{StatusInQA, "ready for QA", regexp.MustCompile(`(?i)\b(ready for qa|in qa|to qa|passed review)\b`)},
+	{StatusInReview, "in review", regexp.MustCompile(`(?i)\b(ready|ready for review|in review|up for review|pr (is )?(up|open|opened))\b`)},
+	{StatusInProgress, "in progress", regexp.MustCompile(`(?i)\b(started|starting|working on|picked up|picking up|in progress)\b`)},
+	{StatusBlocked, "blocked", regexp.MustCompile(`(?i)\bblocked\b`)},
+}
+
+// negation matches a negation just before a claim ("not done", "isn't
+// merged yet", "no longer blocked").
+var negation = regexp.MustCompile(`(?i)\b(not|isn't|isnt|aren't|arent|no longer|never)\s+(\w+\s+)?$`)
+
+// sentenceBreak splits text into sentences.
+var sentenceBreak = regexp.MustCompile(`[.!?]+\s+|\n+`)
+
+// Sentences splits text into sentences, for matching claims to the
+// references they are about.
+func Sentences(text string) []string {
+	var out []string
+	for _, s := range sentenceBreak.Split(text, -1) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Contradiction returns a warning such as "says done, ticket still in-qa"
+// if sentence claims the ticket is further along than it is, or blocked
+// when it is not. It returns "" if the sentence makes no claim or the
+// ticket agrees with it.
+func Contradiction(sentence string, t *Ticket) string {
+	actual, known := progress[t.Status]
+	if !known {
+		return ""
+	}
+	for _, c := range claims {
+		loc := c.re.FindStringIndex(sentence)
+		if loc == nil || negation.MatchString(sentence[:loc[0]]) {
+			continue
+		}
+		if c.status == StatusBlocked {
+			if t.Status != StatusBlocked {
+				return "says blocked, ticket is " + t.Status
+			}
+			return ""
+		}
+		if actual < progress[c.status] {
+			return "says " + c.label + ", ticket still " + t.Status
+		}
+		return ""
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Actor is the audit log actor recorded for retention sweeps.
+const Actor = "retention"
+
+// Worker periodically enforces channel retention policies, moving expired
+// messages to cold storage, and purges channel clears whose restore window
+// has passed. Each sweep is recorded in the audit log.
+type Worker struct {
+	Store    *store.Store
+	Default  models.RetentionPolicy // used by channels without their own policy
+	Interval time.Duration
+}
+
+// Run sweeps once immediately and then every Interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.Sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep applies every channel's retention policy once.
+func (w *Worker) Sweep(ctx context.Context) {
+	start := time.Now()
+	now := start.UTC()
+	sweepID := primitive.NewObjectID()
+
+	channels, err := w.Store.ListChannels(ctx)
+	if err != nil {
+		log.Printf("retention: list channels: %v", err)
+		return
+	}
+
+	archived := make(map[string]int64)
+	var total int64
+	for i := range channels {
+		ch := &channels[i]
+		policy := w.Default
+		if ch.Retention != nil {
+			policy = *ch.Retention
+		}
+		n, err := w.Store.ArchiveExpired(ctx, ch, policy, sweepID, now)
+		if err != nil {
+			log.Printf("retention: archive #%s: %v", ch.Name, err)
+		}
+		if n > 0 {
+			archived[ch.Name] = n
+			total += n
+		}
+	}
+
+	purged, err := w.Store.PurgeExpiredClears(ctx, now)
+	if err != nil {
+		log.Printf("retention: purge expired clears: %v", err)
+	}
+	purgedIDs := make([]string, len(purged))
+	for i, op := range purged {
+		purgedIDs[i] = op.ID.Hex()
+	}
+
+	if total > 0 || len(purged) > 0 {
+		log.Printf("retention: sweep %s archived %d messages, purged %d clears", sweepID.Hex(), total, len(purged))
+	}
+
+	w.Store.CreateAuditEntry(ctx, &models.AuditEntry{
+		Actor:  Actor,
+		Action: "retention.sweep",
+		Details: map[string]any{
+			"sweep_id":       sweepID.Hex(),
+			"channels":       len(channels),
+			"archived":       archived,
+			"archived_total": total,
+			"purged_clears":  purgedIDs,
+			"duration_ms":    time.Since(start).Milliseconds(),
+		},
+	})
+}
@@ -7,28 +7,59 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/devteam/meeting-board/internal/bridge"
 	"github.com/devteam/meeting-board/internal/handlers"
 	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/planning"
 	"github.com/devteam/meeting-board/internal/store"
+	"github.com/devteam/meeting-board/internal/summary"
+	"github.com/devteam/meeting-board/internal/webhooks"
 	"github.com/devteam/meeting-board/internal/ws"
 	"github.com/gorilla/mux"
 )
 
+// Config holds the settings NewServer needs beyond the store and hub.
+type Config struct {
+	Tokens         map[string]string    // legacy role -> bearer token
+	Agents         []models.AgentInfo   // agent registry; may be empty
+	WebFS          fs.FS                // embedded dashboard; nil disables it
+	ClearRetention time.Duration        // how long cleared messages stay restorable
+	Tokenizer      handlers.Tokenizer   // token estimates for context windows; nil for the default
+	Summarizer     summary.Summarizer   // thread and channel summaries; nil for extractive
+	PlanningBoard  planning.Board       // ticket lookups for ticket context; nil disables it
+	TicketThreads  bool                 // track canonical ticket discussion threads
+	Webhooks       *webhooks.Dispatcher // outbound webhook deliveries; nil disables them
+	Bridge         *bridge.Bridge       // Discord/Slack mirror of #humans; nil disables it
+	BridgeSecret   string               // signs replies relayed back through the bridge
+}
+
 // NewServer creates and configures a mux.Router with all routes, middleware, and the
 // embedded web dashboard.
-func NewServer(st *store.Store, hub *ws.Hub, tokens map[string]string, agents []models.AgentInfo, webFS fs.FS) *mux.Router {
+func NewServer(st *store.Store, hub *ws.Hub, cfg Config) *mux.Router {
 	h := &handlers.Handlers{
-		Store:  st,
-		Hub:    hub,
-		Tokens: tokens,
+		Store:          st,
+		Hub:            hub,
+		Tokens:         cfg.Tokens,
+		ClearRetention: cfg.ClearRetention,
+		Tokenizer:      cfg.Tokenizer,
+		Summarizer:     cfg.Summarizer,
+		PlanningBoard:  cfg.PlanningBoard,
+		TicketThreads:  cfg.TicketThreads,
+		Webhooks:       cfg.Webhooks,
+		Bridge:         cfg.Bridge,
+		BridgeSecret:   cfg.BridgeSecret,
 	}
 
+	// Gate WebSocket subscriptions to private channels.
+	hub.Authorize = h.CanSubscribe
+
 	// Initialize agent registry if provided.
-	if len(agents) > 0 {
-		h.SetAgents(agents)
-		log.Printf("Loaded %d agents into registry", len(agents))
+	if len(cfg.Agents) > 0 {
+		h.SetAgents(cfg.Agents)
+		log.Printf("Loaded %d agents into registry", len(cfg.Agents))
 	}
 
 	r := mux.NewRouter()
@@ -40,28 +71,65 @@ func NewServer(st *store.Store, hub *ws.Hub, tokens map[string]string, agents []
 	// Health check (no auth required).
 	r.HandleFunc("/health", h.HealthCheck).Methods("GET")
 
-	// WebSocket endpoint (no auth required; subscriptions are public).
+	// WebSocket endpoint (no auth middleware; hub.Authorize gates
+	// subscriptions to private channels).
 	r.HandleFunc("/ws", h.HandleWebSocket).Methods("GET")
 
+	// Bridge replies from Discord or Slack (signed instead of bearer auth).
+	r.HandleFunc("/bridge/inbound", h.BridgeInbound).Methods("POST")
+
+	// Incoming webhooks (the token in the path is the credential).
+	r.HandleFunc("/hooks/{token}", h.IncomingHook).Methods("POST")
+
 	// API routes with auth middleware.
 	api := r.PathPrefix("/api").Subrouter()
 	api.Use(h.AuthMiddleware)
 
 	api.HandleFunc("/channels", h.ListChannels).Methods("GET")
 	api.HandleFunc("/channels", h.CreateChannel).Methods("POST")
+	api.HandleFunc("/channels/{id}", h.UpdateChannel).Methods("PATCH")
+	api.HandleFunc("/channels/{id}/archive", h.ArchiveChannel).Methods("POST")
 	api.HandleFunc("/channels/{id}/messages", h.ListMessages).Methods("GET")
 	api.HandleFunc("/channels/{id}/messages", h.PostMessage).Methods("POST")
 	api.HandleFunc("/channels/{id}/messages", h.ClearChannel).Methods("DELETE")
+	api.HandleFunc("/channels/{id}/read", h.MarkRead).Methods("PUT")
+	api.HandleFunc("/channels/{id}/context", h.GetContext).Methods("GET")
+	api.HandleFunc("/channels/{id}/summary", h.GetChannelSummary).Methods("GET")
+	api.HandleFunc("/channels/{id}/clears", h.ListClears).Methods("GET")
+	api.HandleFunc("/channels/{id}/pins", h.ListPins).Methods("GET")
+	api.HandleFunc("/channels/{id}/pins/{messageId}", h.PinMessage).Methods("POST")
+	api.HandleFunc("/channels/{id}/pins/{messageId}", h.UnpinMessage).Methods("DELETE")
 	api.HandleFunc("/channels/{id}/threads", h.ListThreads).Methods("GET")
+	api.HandleFunc("/channels/{id}/hooks", h.ListIncomingHooks).Methods("GET")
+	api.HandleFunc("/channels/{id}/hooks", h.CreateIncomingHook).Methods("POST")
+	api.HandleFunc("/channels/{id}/hooks/{hookId}", h.DeleteIncomingHook).Methods("DELETE")
 	api.HandleFunc("/messages", h.ListMessagesByName).Methods("GET")
 	api.HandleFunc("/messages", h.PostMessageByName).Methods("POST")
+	api.HandleFunc("/messages/{id}", h.GetMessage).Methods("GET")
+	api.HandleFunc("/messages/{id}", h.EditMessage).Methods("PATCH")
+	api.HandleFunc("/messages/{id}", h.DeleteMessage).Methods("DELETE")
+	api.HandleFunc("/messages/{id}/reactions", h.AddReaction).Methods("POST")
+	api.HandleFunc("/messages/{id}/reactions", h.RemoveReaction).Methods("DELETE")
+	api.HandleFunc("/threads/{id}", h.GetThread).Methods("GET")
+	api.HandleFunc("/threads/{id}/summary", h.GetThreadSummary).Methods("GET")
+	api.HandleFunc("/clears/{id}/restore", h.RestoreClear).Methods("POST")
 	api.HandleFunc("/mentions", h.GetMentions).Methods("GET")
+	api.HandleFunc("/mentions/{id}/ack", h.AckMention).Methods("POST")
+	api.HandleFunc("/refs/{ref}/messages", h.GetRefMessages).Methods("GET")
+	api.HandleFunc("/feed", h.Feed).Methods("GET")
+	api.HandleFunc("/search", h.Search).Methods("GET")
+	api.HandleFunc("/activity/last", h.GetLastActivity).Methods("GET")
 	api.HandleFunc("/audit", h.ListAudit).Methods("GET")
 	api.HandleFunc("/agents", h.ListAgentsAPI).Methods("GET")
+	api.HandleFunc("/webhooks", h.ListWebhooks).Methods("GET")
+	api.HandleFunc("/webhooks", h.CreateWebhook).Methods("POST")
+	api.HandleFunc("/webhooks/deliveries", h.ListDeliveries).Methods("GET")
+	api.HandleFunc("/webhooks/deliveries/{id}/redeliver", h.RedeliverWebhook).Methods("POST")
+	api.HandleFunc("/webhooks/{id}", h.DeleteWebhook).Methods("DELETE")
 
 	// Serve the embedded web dashboard at /.
-	if webFS != nil {
-		fileServer := http.FileServer(http.FS(webFS))
+	if cfg.WebFS != nil {
+		fileServer := http.FileServer(http.FS(cfg.WebFS))
 		r.PathPrefix("/").Handler(fileServer)
 	}
 
@@ -72,7 +140,7 @@ func NewServer(st *store.Store, hub *ws.Hub, tokens map[string]string, agents []
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
@@ -91,10 +159,19 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		start := time.Now()
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 		next.ServeHTTP(rw, r)
-		log.Printf("%s %s %d %s", r.Method, r.URL.Path, rw.statusCode, time.Since(start))
+		log.Printf("%s %s %d %s", r.Method, logPath(r.URL.Path), rw.statusCode, time.Since(start))
 	})
 }
 
+// logPath returns a request path fit for the access log: the token of an
+// incoming webhook is its only credential, so it is redacted.
+func logPath(path string) string {
+	if strings.HasPrefix(path, "/hooks/") {
+		return "/hooks/{token}"
+	}
+	return path
+}
+
 // responseWriter wraps http.ResponseWriter to capture the status code.
 type responseWriter struct {
 	http.ResponseWriter
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Activity operations
+// ---------------------------------------------------------------------------
+
+// GetLastMessage returns the most recent message in the given channels, or
+// mongo.ErrNoDocuments if they have none.
+func (s *Store) GetLastMessage(ctx context.Context, channelIDs []primitive.ObjectID) (*models.Message, error) {
+	if len(channelIDs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	filter := bson.M{"channel_id": bson.M{"$in": channelIDs}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	var msg models.Message
+	if err := s.messages.FindOne(ctx, filter, opts).Decode(&msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// GetChannelStats computes per-channel activity stats for every channel with
+// at least one message, keyed by channel ID. caller is the reader whose read
+// positions and unread counts are computed. It runs two aggregations
+// regardless of the number of channels: one for totals and the caller's last
+// post, and one counting unread messages above each read position.
+func (s *Store) GetChannelStats(ctx context.Context, caller string) (map[primitive.ObjectID]*models.ChannelStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "channel_id", Value: 1}, {Key: "created_at", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$channel_id",
+			"message_count":    bson.M{"$sum": 1},
+			"last_message_at":  bson.M{"$last": "$created_at"},
+			"last_author":      bson.M{"$last": "$author"},
+			"last_author_name": bson.M{"$last": "$author_name"},
+			"last_seq":         bson.M{"$max": "$seq"},
+			"own_last_seq": bson.M{"$max": bson.M{
+				"$cond": bson.A{bson.M{"$eq": bson.A{"$author", caller}}, "$seq", 0},
+			}},
+		}}},
+	}
+
+	cursor, err := s.messages.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ChannelID           primitive.ObjectID `bson:"_id"`
+		models.ChannelStats `bson:",inline"`
+		OwnLastSeq          int64 `bson:"own_last_seq"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	markers, err := s.readMarkers(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[primitive.ObjectID]*models.ChannelStats, len(rows))
+	unreadIn := make([]bson.M, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		row.ReadSeq = max(markers[row.ChannelID], row.OwnLastSeq)
+		stats[row.ChannelID] = &row.ChannelStats
+		unreadIn = append(unreadIn, unreadFilter(row.ChannelID, caller, row.ReadSeq))
+	}
+	if len(unreadIn) == 0 {
+		return stats, nil
+	}
+
+	unreadPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": unreadIn}}},
+		{{Key: "$group", Value: bson.M{"_id": "$channel_id", "unread": bson.M{"$sum": 1}}}},
+	}
+	unreadCursor, err := s.messages.Aggregate(ctx, unreadPipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer unreadCursor.Close(ctx)
+
+	var unread []struct {
+		ChannelID primitive.ObjectID `bson:"_id"`
+		Unread    int64              `bson:"unread"`
+	}
+	if err := unreadCursor.All(ctx, &unread); err != nil {
+		return nil, err
+	}
+	for _, u := range unread {
+		if st, ok := stats[u.ChannelID]; ok {
+			st.Unread = u.Unread
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Channel clear operations
+// ---------------------------------------------------------------------------
+
+// Errors returned by RestoreClear.
+var (
+	ErrClearRestored = errors.New("clear operation already restored")
+	ErrClearExpired  = errors.New("clear operation is past its retention window")
+)
+
+// archiveFields are added to documents moved into an archive collection and
+// stripped again on restore.
+var archiveFields = []string{"clear_id", "archived_at"}
+
+// ClearChannel moves every message in a channel, along with its mention inbox
+// items, into the archive collections under a new clear operation that can be
+// restored until retention has elapsed, and unpins the moved messages.
+// Messages posted while the clear runs are left in place.
+func (s *Store) ClearChannel(ctx context.Context, ch *models.Channel, actor string, retention time.Duration) (*models.ClearOperation, error) {
+	now := time.Now().UTC()
+	op := &models.ClearOperation{
+		ID:          primitive.NewObjectID(),
+		ChannelID:   ch.ID,
+		ChannelName: ch.Name,
+		Actor:       actor,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(retention),
+	}
+
+	match := bson.M{"channel_id": ch.ID, "created_at": bson.M{"$lte": now}}
+	count, err := s.moveDocuments(ctx, s.messages, s.archivedMessages, match, bson.M{"clear_id": op.ID, "archived_at": now})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.moveDocuments(ctx, s.mentions, s.archivedMentions, match, bson.M{"clear_id": op.ID, "archived_at": now}); err != nil {
+		return nil, err
+	}
+	if len(ch.Pins) > 0 {
+		ids, err := s.archivedMessages.Distinct(ctx, "_id", bson.M{"clear_id": op.ID})
+		if err != nil {
+			return nil, err
+		}
+		if err := s.unpinMessages(ctx, ch.ID, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	op.MessageCount = count
+	if _, err := s.clears.InsertOne(ctx, op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// moveDocuments copies the documents matching filter from src into dst with
+// the extra fields set, then deletes them from src. It returns the number of
+// documents moved.
+func (s *Store) moveDocuments(ctx context.Context, src, dst *mongo.Collection, filter, extra bson.M) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: extra}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           dst.Name(),
+			"on":             "_id",
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+	cursor, err := src.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	cursor.Close(ctx)
+
+	// Only delete what actually reached the archive.
+	archived := bson.M{}
+	for k, v := range extra {
+		archived[k] = v
+	}
+	ids, err := dst.Distinct(ctx, "_id", archived)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res, err := src.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// restoreDocuments moves documents matching filter from an archive collection
+// back into dst, stripping the archive bookkeeping fields.
+func (s *Store) restoreDocuments(ctx context.Context, archive, dst *mongo.Collection, filter bson.M) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$unset", Value: archiveFields}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           dst.Name(),
+			"on":             "_id",
+			"whenMatched":    "keepExisting",
+			"whenNotMatched": "insert",
+		}}},
+	}
+	cursor, err := archive.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	cursor.Close(ctx)
+
+	res, err := archive.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// ListClears returns the clear operations for a channel, newest first.
+func (s *Store) ListClears(ctx context.Context, channelID primitive.ObjectID) ([]models.ClearOperation, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.clears.Find(ctx, bson.M{"channel_id": channelID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ops []models.ClearOperation
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, err
+	}
+	if ops == nil {
+		ops = []models.ClearOperation{}
+	}
+	return ops, nil
+}
+
+// GetClear retrieves a clear operation by its ObjectID.
+func (s *Store) GetClear(ctx context.Context, id primitive.ObjectID) (*models.ClearOperation, error) {
+	var op models.ClearOperation
+	if err := s.clears.FindOne(ctx, bson.M{"_id": id}).Decode(&op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// RestoreClear moves the messages and mention inbox items archived by a clear
+// operation back into the live collections and marks the operation restored.
+func (s *Store) RestoreClear(ctx context.Context, id primitive.ObjectID, actor string) (*models.ClearOperation, error) {
+	op, err := s.GetClear(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if op.RestoredAt != nil {
+		return nil, ErrClearRestored
+	}
+	now := time.Now().UTC()
+	if now.After(op.ExpiresAt) {
+		return nil, ErrClearExpired
+	}
+
+	// Claim the operation first so concurrent restores cannot both run.
+	res, err := s.clears.UpdateOne(ctx,
+		bson.M{"_id": id, "restored_at": nil},
+		bson.M{"$set": bson.M{"restored_at": now, "restored_by": actor}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.ModifiedCount == 0 {
+		return nil, ErrClearRestored
+	}
+
+	filter := bson.M{"clear_id": id}
+	if _, err := s.restoreDocuments(ctx, s.archivedMessages, s.messages, filter); err != nil {
+		return nil, err
+	}
+	if _, err := s.restoreDocuments(ctx, s.archivedMentions, s.mentions, filter); err != nil {
+		return nil, err
+	}
+
+	op.RestoredAt = &now
+	op.RestoredBy = actor
+	return op, nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Message edit and delete operations
+// ---------------------------------------------------------------------------
+
+// ErrMessageDeleted is returned when editing or deleting a message that has
+// already been deleted.
+var ErrMessageDeleted = errors.New("message is deleted")
+
+// reviseMessage atomically pushes the message's current content and mentions
+// onto its history and applies set. It returns the message as it was before
+// and after the update. Deleted messages are never revised.
+func (s *Store) reviseMessage(ctx context.Context, id primitive.ObjectID, by string, set bson.M) (before, after *models.Message, err error) {
+	now := time.Now().UTC()
+	revision := bson.M{
+		"content":     "$content",
+		"mentions":    "$mentions",
+		"replaced_at": now,
+		"replaced_by": by,
+	}
+	set["history"] = bson.M{"$concatArrays": bson.A{
+		bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+		bson.A{revision},
+	}}
+	// Literal values in a pipeline update must not be mistaken for field
+	// paths or operators, so wrap them in $literal.
+	for k, v := range set {
+		if k != "history" {
+			set[k] = bson.M{"$literal": v}
+		}
+	}
+
+	filter := bson.M{"_id": id, "deleted": bson.M{"$ne": true}}
+	pipeline := mongo.Pipeline{{{Key: "$set", Value: set}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var old models.Message
+	err = s.messages.FindOneAndUpdate(ctx, filter, pipeline, opts).Decode(&old)
+	if err == mongo.ErrNoDocuments {
+		if existing, getErr := s.GetMessageByID(ctx, id); getErr == nil && existing.Deleted {
+			return nil, nil, ErrMessageDeleted
+		}
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated, err := s.GetMessageByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &old, updated, nil
+}
+
+// EditMessage replaces a message's content and mentions, keeping the previous
+// version in its history, and brings the mention inbox in line with the new
+// mentions. It returns the message before and after the edit.
+func (s *Store) EditMessage(ctx context.Context, id primitive.ObjectID, content string, mentions []string, by string) (before, after *models.Message, err error) {
+	if mentions == nil {
+		mentions = []string{}
+	}
+	before, after, err = s.reviseMessage(ctx, id, by, bson.M{
+		"content":   content,
+		"mentions":  mentions,
+		"refs":      s.ExtractRefs(content),
+		"edited_at": time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.syncMentions(ctx, after); err != nil {
+		log.Printf("store: sync mentions for %s: %v", id.Hex(), err)
+	}
+	return before, after, nil
+}
+
+// DeleteMessage turns a message into a tombstone: its content and mentions
+// are cleared (the previous version is kept in its history) and it is marked
+// deleted. The document itself stays so threads and sequence numbers remain
+// intact. Open mention inbox items for the message are removed.
+func (s *Store) DeleteMessage(ctx context.Context, id primitive.ObjectID, by string) (before, after *models.Message, err error) {
+	before, after, err = s.reviseMessage(ctx, id, by, bson.M{
+		"content":    "",
+		"mentions":   []string{},
+		"refs":       []string{},
+		"deleted":    true,
+		"deleted_at": time.Now().UTC(),
+		"deleted_by": by,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.syncMentions(ctx, after); err != nil {
+		log.Printf("store: sync mentions for %s: %v", id.Hex(), err)
+	}
+	return before, after, nil
+}
+
+// syncMentions reconciles the mention inbox with msg.Mentions after an edit:
+// open items for recipients no longer mentioned are removed, remaining open
+// items get the new content, and new recipients get an inbox item.
+// Responded items are left untouched as a record of what was answered.
+func (s *Store) syncMentions(ctx context.Context, msg *models.Message) error {
+	_, err := s.mentions.DeleteMany(ctx, bson.M{
+		"message_id": msg.ID,
+		"responded":  false,
+		"recipient":  bson.M{"$nin": msg.Mentions},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.mentions.UpdateMany(ctx,
+		bson.M{"message_id": msg.ID, "responded": false},
+		bson.M{"$set": bson.M{"content": msg.Content}},
+	)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.mentions.Distinct(ctx, "recipient", bson.M{"message_id": msg.ID})
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		if name, ok := r.(string); ok {
+			have[name] = true
+		}
+	}
+	added := *msg
+	added.Mentions = nil
+	for _, m := range msg.Mentions {
+		if !have[m] {
+			added.Mentions = append(added.Mentions, m)
+		}
+	}
+	return s.recordMentions(ctx, &added)
+}
@@ -0,0 +1,218 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Digest feed
+// ---------------------------------------------------------------------------
+
+// Reasons a message appears in a reader's feed.
+const (
+	FeedReasonChannel = "channel" // unread in a followed channel
+	FeedReasonMention = "mention" // mentions the reader
+	FeedReasonThread  = "thread"  // reply in a thread the reader took part in
+)
+
+// FeedQuery selects a reader's feed. Positions holds the reader's read
+// position in every channel it can see; only messages above those positions
+// are considered.
+type FeedQuery struct {
+	Reader    string
+	Aliases   []string // handles that address the reader in mentions (ID, role)
+	Followed  []primitive.ObjectID
+	Positions map[primitive.ObjectID]int64
+	Limit     int64
+}
+
+// FeedItem is a message in a reader's feed with why it was included.
+type FeedItem struct {
+	models.Message `bson:",inline"`
+	Reasons        []string `json:"reasons" bson:"-"`
+}
+
+// Feed returns, oldest first, the unread messages from others that are in a
+// followed channel, mention the reader, or reply to a thread the reader has
+// posted in. Each message appears once, with every reason that applies.
+// hasMore reports whether the limit cut the feed short.
+func (s *Store) Feed(ctx context.Context, q FeedQuery) (items []FeedItem, hasMore bool, err error) {
+	if len(q.Positions) == 0 {
+		return []FeedItem{}, false, nil
+	}
+
+	visible := make([]primitive.ObjectID, 0, len(q.Positions))
+	unread := make([]bson.M, 0, len(q.Positions))
+	for channelID, position := range q.Positions {
+		visible = append(visible, channelID)
+		unread = append(unread, unreadFilter(channelID, q.Reader, position))
+	}
+
+	threads, err := s.participatedThreads(ctx, q.Reader, visible)
+	if err != nil {
+		return nil, false, err
+	}
+	seen, err := s.feedSeen(ctx, q.Reader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	relevant := []bson.M{{"mentions": bson.M{"$in": q.Aliases}}}
+	if len(q.Followed) > 0 {
+		relevant = append(relevant, bson.M{"channel_id": bson.M{"$in": q.Followed}})
+	}
+	if len(threads) > 0 {
+		relevant = append(relevant, bson.M{"thread_id": bson.M{"$in": threads}})
+	}
+	filter := bson.M{"$and": []bson.M{{"$or": unread}, {"$or": relevant}}}
+	if len(seen) > 0 {
+		filter["_id"] = bson.M{"$nin": seen}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	if q.Limit > 0 {
+		opts.SetLimit(q.Limit + 1)
+	}
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, false, err
+	}
+	if items == nil {
+		items = []FeedItem{}
+	}
+	if q.Limit > 0 && int64(len(items)) > q.Limit {
+		items, hasMore = items[:q.Limit], true
+	}
+
+	followed := make(map[primitive.ObjectID]bool, len(q.Followed))
+	for _, id := range q.Followed {
+		followed[id] = true
+	}
+	inThread := make(map[primitive.ObjectID]bool, len(threads))
+	for _, id := range threads {
+		inThread[id] = true
+	}
+	for i := range items {
+		item := &items[i]
+		if followed[item.ChannelID] {
+			item.Reasons = append(item.Reasons, FeedReasonChannel)
+		}
+		if mentionsAny(item.Mentions, q.Aliases) {
+			item.Reasons = append(item.Reasons, FeedReasonMention)
+		}
+		if item.ThreadID != nil && inThread[*item.ThreadID] {
+			item.Reasons = append(item.Reasons, FeedReasonThread)
+		}
+	}
+	return items, hasMore, nil
+}
+
+// participatedThreads returns the IDs of threads in the given channels that
+// the reader started or replied to.
+func (s *Store) participatedThreads(ctx context.Context, reader string, channels []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	filter := bson.M{"author": reader, "channel_id": bson.M{"$in": channels}}
+	cursor, err := s.messages.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1, "thread_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var own []models.Message
+	if err := cursor.All(ctx, &own); err != nil {
+		return nil, err
+	}
+	seen := make(map[primitive.ObjectID]bool)
+	var threads []primitive.ObjectID
+	for _, m := range own {
+		root := m.ID
+		if m.ThreadID != nil {
+			root = *m.ThreadID
+		}
+		if !seen[root] {
+			seen[root] = true
+			threads = append(threads, root)
+		}
+	}
+	return threads, nil
+}
+
+// feedSeen returns the IDs of the messages the reader has seen in the feed
+// above its read markers.
+func (s *Store) feedSeen(ctx context.Context, reader string) ([]primitive.ObjectID, error) {
+	filter := bson.M{"reader": reader, "seen.0": bson.M{"$exists": true}}
+	cursor, err := s.reads.Find(ctx, filter, options.Find().SetProjection(bson.M{"seen": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var markers []models.ReadMarker
+	if err := cursor.All(ctx, &markers); err != nil {
+		return nil, err
+	}
+	var ids []primitive.ObjectID
+	for _, m := range markers {
+		for _, seen := range m.Seen {
+			ids = append(ids, seen.ID)
+		}
+	}
+	return ids, nil
+}
+
+// MarkFeedSeen records messages in a channel as seen in the reader's feed
+// without moving its read marker, so the channel's other unread messages
+// stay unread. Later feeds skip them.
+func (s *Store) MarkFeedSeen(ctx context.Context, reader string, channelID primitive.ObjectID, seen []models.SeenMessage) error {
+	if len(seen) == 0 {
+		return nil
+	}
+	_, err := s.reads.UpdateOne(ctx,
+		bson.M{"reader": reader, "channel_id": channelID},
+		bson.M{
+			"$addToSet": bson.M{"seen": bson.M{"$each": seen}},
+			"$set":      bson.M{"updated_at": time.Now().UTC()},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// AdvanceReadMarker moves the reader's marker in a channel forward to seq.
+// It never moves a marker backwards, so concurrent readers cannot undo each
+// other's progress. The marker's message_id is dropped, as it is now
+// positioned by seq alone, and seen messages it has passed are forgotten.
+func (s *Store) AdvanceReadMarker(ctx context.Context, reader string, channelID primitive.ObjectID, seq int64) error {
+	_, err := s.reads.UpdateOne(ctx,
+		bson.M{"reader": reader, "channel_id": channelID},
+		bson.M{
+			"$max":   bson.M{"seq": seq},
+			"$set":   bson.M{"updated_at": time.Now().UTC()},
+			"$unset": bson.M{"message_id": ""},
+			"$pull":  bson.M{"seen": bson.M{"seq": bson.M{"$lte": seq}}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// mentionsAny reports whether mentions contains any of handles.
+func mentionsAny(mentions, handles []string) bool {
+	for _, m := range mentions {
+		for _, h := range handles {
+			if m == h {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Incoming webhooks
+// ---------------------------------------------------------------------------
+
+// CreateIncomingHook inserts an incoming webhook.
+func (s *Store) CreateIncomingHook(ctx context.Context, hook *models.IncomingHook) error {
+	hook.CreatedAt = time.Now().UTC()
+	res, err := s.incomingHooks.InsertOne(ctx, hook)
+	if err != nil {
+		return err
+	}
+	hook.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetIncomingHookByToken returns the incoming webhook with the given token,
+// or mongo.ErrNoDocuments.
+func (s *Store) GetIncomingHookByToken(ctx context.Context, token string) (*models.IncomingHook, error) {
+	var hook models.IncomingHook
+	if err := s.incomingHooks.FindOne(ctx, bson.M{"token": token}).Decode(&hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// ListIncomingHooks returns a channel's incoming webhooks, oldest first.
+func (s *Store) ListIncomingHooks(ctx context.Context, channelID primitive.ObjectID) ([]models.IncomingHook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := s.incomingHooks.Find(ctx, bson.M{"channel_id": channelID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hooks := []models.IncomingHook{}
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// DeleteIncomingHook removes one of a channel's incoming webhooks. It
+// returns mongo.ErrNoDocuments if the channel has no such hook.
+func (s *Store) DeleteIncomingHook(ctx context.Context, channelID, id primitive.ObjectID) error {
+	res, err := s.incomingHooks.DeleteOne(ctx, bson.M{"_id": id, "channel_id": channelID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// TouchIncomingHook records that an incoming webhook was just used.
+func (s *Store) TouchIncomingHook(ctx context.Context, id primitive.ObjectID) error {
+	_, err := s.incomingHooks.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": time.Now().UTC()}})
+	return err
+}
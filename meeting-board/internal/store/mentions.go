@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Mention inbox operations
+// ---------------------------------------------------------------------------
+
+// MentionFilter selects items from the mention inbox.
+type MentionFilter struct {
+	Recipients []string            // Match any of these recipients; empty matches all.
+	Responded  *bool               // nil matches both responded and unresponded.
+	Since      *time.Time          // Only mentions created strictly after this time.
+	ChannelID  *primitive.ObjectID // Only mentions in this channel.
+	ChannelIDs []primitive.ObjectID // Only mentions in these channels; nil matches all.
+}
+
+// recordMentions creates an inbox item for every recipient mentioned in msg.
+// Authors never receive an inbox item for mentioning themselves.
+func (s *Store) recordMentions(ctx context.Context, msg *models.Message) error {
+	var docs []any
+	for _, recipient := range msg.Mentions {
+		if recipient == msg.Author {
+			continue
+		}
+		docs = append(docs, &models.Mention{
+			MessageID:  msg.ID,
+			ChannelID:  msg.ChannelID,
+			Seq:        msg.Seq,
+			ThreadID:   msg.ThreadID,
+			Recipient:  recipient,
+			Author:     msg.Author,
+			AuthorName: msg.AuthorName,
+			AuthorRole: msg.AuthorRole,
+			Content:    msg.Content,
+			CreatedAt:  msg.CreatedAt,
+		})
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	_, err := s.mentions.InsertMany(ctx, docs)
+	return err
+}
+
+// markMentionsResponded marks as responded every open mention addressed to
+// the author of msg (by ID or role) that msg answers. A thread reply answers
+// mentions in that thread, including its root; a top-level message answers
+// top-level mentions in the same channel.
+func (s *Store) markMentionsResponded(ctx context.Context, msg *models.Message) error {
+	responders := []string{msg.Author}
+	if msg.AuthorRole != "" && msg.AuthorRole != msg.Author {
+		responders = append(responders, msg.AuthorRole)
+	}
+
+	filter := bson.M{
+		"recipient":  bson.M{"$in": responders},
+		"responded":  false,
+		"channel_id": msg.ChannelID,
+		"created_at": bson.M{"$lte": msg.CreatedAt},
+	}
+	if msg.ThreadID != nil {
+		filter["$or"] = []bson.M{
+			{"thread_id": *msg.ThreadID},
+			{"message_id": *msg.ThreadID},
+		}
+	} else {
+		filter["thread_id"] = nil
+	}
+
+	update := bson.M{"$set": bson.M{
+		"responded":     true,
+		"responded_at":  msg.CreatedAt,
+		"responded_via": "reply",
+		"response_id":   msg.ID,
+	}}
+	_, err := s.mentions.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// ListMentions returns a page of inbox items matching the filter, ordered by
+// created_at ascending.
+func (s *Store) ListMentions(ctx context.Context, f MentionFilter, page Page) ([]models.Mention, PageInfo, error) {
+	filter := bson.M{}
+	if len(f.Recipients) > 0 {
+		filter["recipient"] = bson.M{"$in": f.Recipients}
+	}
+	if f.Responded != nil {
+		filter["responded"] = *f.Responded
+	}
+	if f.Since != nil {
+		filter["created_at"] = bson.M{"$gt": *f.Since}
+	}
+	if f.ChannelIDs != nil {
+		filter["channel_id"] = bson.M{"$in": f.ChannelIDs}
+	}
+	if f.ChannelID != nil {
+		filter["channel_id"] = *f.ChannelID
+	}
+	return findPage(ctx, s.mentions, filter, "created_at", page, true, func(m *models.Mention) Cursor {
+		return Cursor{Time: m.CreatedAt, ID: m.ID}
+	})
+}
+
+// GetMentionByID retrieves a single inbox item by its ObjectID.
+func (s *Store) GetMentionByID(ctx context.Context, id primitive.ObjectID) (*models.Mention, error) {
+	var m models.Mention
+	err := s.mentions.FindOne(ctx, bson.M{"_id": id}).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// AckMention marks an inbox item as responded via an explicit acknowledgement
+// and returns the updated item. Acknowledging an already-responded mention is
+// a no-op that returns the item unchanged.
+func (s *Store) AckMention(ctx context.Context, id primitive.ObjectID) (*models.Mention, error) {
+	now := time.Now().UTC()
+	filter := bson.M{"_id": id, "responded": false}
+	update := bson.M{"$set": bson.M{
+		"responded":     true,
+		"responded_at":  now,
+		"responded_via": "ack",
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var m models.Mention
+	err := s.mentions.FindOneAndUpdate(ctx, filter, update, opts).Decode(&m)
+	if err == mongo.ErrNoDocuments {
+		return s.GetMentionByID(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Cursor pagination
+// ---------------------------------------------------------------------------
+
+// ErrInvalidCursor is returned by DecodeCursor for malformed cursors.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// maxObjectID sorts after every real ObjectID. A cursor at (t, maxObjectID)
+// selects documents strictly after t, which is how "since" filters are
+// expressed as cursors.
+var maxObjectID = primitive.ObjectID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// Cursor is a position in a result set ordered by (timestamp, _id). The
+// _id tiebreak means documents sharing a timestamp are never skipped.
+type Cursor struct {
+	Time time.Time
+	ID   primitive.ObjectID
+}
+
+// CursorAfter returns a cursor positioned after every document at t, so that
+// paging forward from it is equivalent to a strict "since t" filter.
+func CursorAfter(t time.Time) *Cursor {
+	return &Cursor{Time: t, ID: maxObjectID}
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.UnixMilli(), 10) + "." + c.ID.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ms, hex, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	millis, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Time: time.UnixMilli(millis).UTC(), ID: id}, nil
+}
+
+// Page selects a window of a (timestamp, _id) ordered result set.
+// After pages towards newer documents; Before (or neither) pages towards
+// older ones, so a request with no cursor returns the newest page.
+type Page struct {
+	Before *Cursor
+	After  *Cursor
+	Offset int64 // Documents to skip in the direction of travel.
+	Limit  int64 // 0 means no limit.
+}
+
+// PageInfo describes a returned page. PrevCursor points at the oldest
+// document (pass it as "before" for older results) and NextCursor at the
+// newest (pass it as "after" for newer results). HasMore reports whether
+// further documents exist in the direction of travel.
+type PageInfo struct {
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// cursorRange returns the filter selecting documents strictly after (gt) or
+// before the cursor on the (field, _id) ordering.
+func cursorRange(field string, c *Cursor, op string) bson.M {
+	return bson.M{"$or": []bson.M{
+		{field: bson.M{op: c.Time}},
+		{field: c.Time, "_id": bson.M{op: c.ID}},
+	}}
+}
+
+// findPage runs a paginated query on coll ordered by (field, _id) and returns
+// the documents in ascending order if ascending is true, descending otherwise.
+// key extracts a document's cursor position.
+func findPage[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, field string, p Page, ascending bool, key func(*T) Cursor) ([]T, PageInfo, error) {
+	var conds []bson.M
+	if p.After != nil {
+		conds = append(conds, cursorRange(field, p.After, "$gt"))
+	}
+	if p.Before != nil {
+		conds = append(conds, cursorRange(field, p.Before, "$lt"))
+	}
+	if len(conds) > 0 {
+		if existing, ok := filter["$and"].([]bson.M); ok {
+			conds = append(existing, conds...)
+		}
+		filter["$and"] = conds
+	}
+
+	// Travel forward (oldest first) from an "after" cursor, otherwise
+	// backwards from the newest document.
+	forward := p.After != nil
+	dir := -1
+	if forward {
+		dir = 1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}}).
+		SetSkip(p.Offset)
+	if p.Limit > 0 {
+		opts.SetLimit(p.Limit + 1)
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("find page: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []T
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var info PageInfo
+	if p.Limit > 0 && int64(len(docs)) > p.Limit {
+		info.HasMore = true
+		docs = docs[:p.Limit]
+	}
+	if docs == nil {
+		docs = []T{}
+	}
+
+	if len(docs) > 0 {
+		first, last := key(&docs[0]), key(&docs[len(docs)-1])
+		if !forward {
+			first, last = last, first
+		}
+		info.PrevCursor, info.NextCursor = first.Encode(), last.Encode()
+	} else if p.After != nil {
+		// Nothing newer yet: keep handing back the same cursor so pollers
+		// can simply retry.
+		info.NextCursor = p.After.Encode()
+	}
+
+	if forward != ascending {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+	return docs, info, nil
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ---------------------------------------------------------------------------
+// Pinned messages
+// ---------------------------------------------------------------------------
+
+// ErrPinLimit is returned by PinMessage when the channel already has the
+// maximum number of pins.
+var ErrPinLimit = errors.New("channel pin limit reached")
+
+// PinMessage appends pin to the channel's pins unless the message is already
+// pinned or the channel already has limit pins; both checks happen in the
+// same update, so concurrent pins cannot exceed the limit. added is false if
+// the message was already pinned.
+func (s *Store) PinMessage(ctx context.Context, channelID primitive.ObjectID, pin models.Pin, limit int) (added bool, err error) {
+	// The channel has room if there is no element at index limit-1.
+	lastSlot := fmt.Sprintf("pins.%d", limit-1)
+	filter := bson.M{
+		"_id":             channelID,
+		"pins.message_id": bson.M{"$ne": pin.MessageID},
+		lastSlot:          bson.M{"$exists": false},
+	}
+	res, err := s.channels.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"pins": pin}})
+	if err != nil {
+		return false, err
+	}
+	if res.ModifiedCount > 0 {
+		return true, nil
+	}
+
+	ch, err := s.GetChannelByID(ctx, channelID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range ch.Pins {
+		if p.MessageID == pin.MessageID {
+			return false, nil
+		}
+	}
+	return false, ErrPinLimit
+}
+
+// UnpinMessage removes a message from the channel's pins. removed is false if
+// it was not pinned.
+func (s *Store) UnpinMessage(ctx context.Context, channelID, messageID primitive.ObjectID) (removed bool, err error) {
+	res, err := s.channels.UpdateOne(ctx,
+		bson.M{"_id": channelID},
+		bson.M{"$pull": bson.M{"pins": bson.M{"message_id": messageID}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// unpinMessages removes the given messages from the channel's pins.
+func (s *Store) unpinMessages(ctx context.Context, channelID primitive.ObjectID, messageIDs []any) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	_, err := s.channels.UpdateOne(ctx,
+		bson.M{"_id": channelID},
+		bson.M{"$pull": bson.M{"pins": bson.M{"message_id": bson.M{"$in": messageIDs}}}},
+	)
+	return err
+}
+
+// ListPins returns the channel's pinned messages, most recently pinned first.
+// Pins whose message has been cleared or archived are skipped.
+func (s *Store) ListPins(ctx context.Context, ch *models.Channel) ([]models.PinnedMessage, error) {
+	pinned := []models.PinnedMessage{}
+	if len(ch.Pins) == 0 {
+		return pinned, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(ch.Pins))
+	for i, p := range ch.Pins {
+		ids[i] = p.MessageID
+	}
+	cursor, err := s.messages.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var msgs []models.Message
+	if err := cursor.All(ctx, &msgs); err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]models.Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.ID] = m
+	}
+
+	for i := len(ch.Pins) - 1; i >= 0; i-- {
+		p := ch.Pins[i]
+		msg, ok := byID[p.MessageID]
+		if !ok {
+			continue
+		}
+		pinned = append(pinned, models.PinnedMessage{Message: msg, PinnedBy: p.PinnedBy, PinnedAt: p.PinnedAt})
+	}
+	return pinned, nil
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Reaction operations
+// ---------------------------------------------------------------------------
+
+// AddReaction adds r to a message and bumps its count, then marks any open
+// mention of the reacting actor in that message as responded. added is false
+// if the actor had already added the same reaction, in which case the message
+// is returned unchanged. Deleted messages cannot be reacted to.
+func (s *Store) AddReaction(ctx context.Context, id primitive.ObjectID, r models.Reaction) (msg *models.Message, added bool, err error) {
+	r.CreatedAt = time.Now().UTC()
+	filter := bson.M{
+		"_id":       id,
+		"deleted":   bson.M{"$ne": true},
+		"reactions": bson.M{"$not": bson.M{"$elemMatch": bson.M{"name": r.Name, "actor": r.Actor}}},
+	}
+	update := bson.M{
+		"$push": bson.M{"reactions": r},
+		"$inc":  bson.M{"reaction_counts." + r.Name: 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.Message
+	err = s.messages.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		existing, getErr := s.GetMessageByID(ctx, id)
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		if existing.Deleted {
+			return nil, false, ErrMessageDeleted
+		}
+		return existing, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.markMentionsReacted(ctx, &updated, r); err != nil {
+		log.Printf("store: mark mentions reacted: %v", err)
+	}
+	return &updated, true, nil
+}
+
+// RemoveReaction removes the actor's reaction from a message and decrements
+// its count, dropping the count once it reaches zero. removed is false if the
+// actor had not added that reaction.
+func (s *Store) RemoveReaction(ctx context.Context, id primitive.ObjectID, name, actor string) (msg *models.Message, removed bool, err error) {
+	countField := "reaction_counts." + name
+	filter := bson.M{
+		"_id":       id,
+		"reactions": bson.M{"$elemMatch": bson.M{"name": name, "actor": actor}},
+	}
+	update := bson.M{
+		"$pull": bson.M{"reactions": bson.M{"name": name, "actor": actor}},
+		"$inc":  bson.M{countField: -1},
+	}
+	res, err := s.messages.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, false, err
+	}
+	if res.ModifiedCount > 0 {
+		_, err := s.messages.UpdateOne(ctx,
+			bson.M{"_id": id, countField: bson.M{"$lte": 0}},
+			bson.M{"$unset": bson.M{countField: ""}},
+		)
+		if err != nil {
+			log.Printf("store: drop empty reaction count: %v", err)
+		}
+	}
+
+	msg, err = s.GetMessageByID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return msg, res.ModifiedCount > 0, nil
+}
+
+// markMentionsReacted marks as responded every open mention of the reacting
+// actor (by ID or role) in msg itself.
+func (s *Store) markMentionsReacted(ctx context.Context, msg *models.Message, r models.Reaction) error {
+	responders := []string{r.Actor}
+	if r.ActorRole != "" && r.ActorRole != r.Actor {
+		responders = append(responders, r.ActorRole)
+	}
+
+	filter := bson.M{
+		"message_id": msg.ID,
+		"recipient":  bson.M{"$in": responders},
+		"responded":  false,
+	}
+	update := bson.M{"$set": bson.M{
+		"responded":     true,
+		"responded_at":  r.CreatedAt,
+		"responded_via": "reaction",
+	}}
+	_, err := s.mentions.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Read markers
+// ---------------------------------------------------------------------------
+
+// SetReadMarker creates or replaces the reader's marker for a channel. The
+// marker may move backwards, which marks messages unread again, including
+// those already seen in the feed.
+func (s *Store) SetReadMarker(ctx context.Context, m *models.ReadMarker) error {
+	m.UpdatedAt = time.Now().UTC()
+	filter := bson.M{"reader": m.Reader, "channel_id": m.ChannelID}
+	update := bson.M{
+		"$set": bson.M{
+			"seq":        m.Seq,
+			"message_id": m.MessageID,
+			"updated_at": m.UpdatedAt,
+		},
+		"$unset": bson.M{"seen": ""},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	return s.reads.FindOneAndUpdate(ctx, filter, update, opts).Decode(m)
+}
+
+// readMarkers returns the reader's marker seq for every channel it has one in.
+func (s *Store) readMarkers(ctx context.Context, reader string) (map[primitive.ObjectID]int64, error) {
+	cursor, err := s.reads.Find(ctx, bson.M{"reader": reader})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var markers []models.ReadMarker
+	if err := cursor.All(ctx, &markers); err != nil {
+		return nil, err
+	}
+	seqs := make(map[primitive.ObjectID]int64, len(markers))
+	for _, m := range markers {
+		seqs[m.ChannelID] = m.Seq
+	}
+	return seqs, nil
+}
+
+// ReadPosition returns the reader's effective read position in a channel:
+// the later of its read marker and its own latest message. It is 0 if the
+// reader has neither.
+func (s *Store) ReadPosition(ctx context.Context, reader string, channelID primitive.ObjectID) (int64, error) {
+	var marker models.ReadMarker
+	err := s.reads.FindOne(ctx, bson.M{"reader": reader, "channel_id": channelID}).Decode(&marker)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, err
+	}
+
+	own, err := s.lastSeq(ctx, bson.M{"channel_id": channelID, "author": reader})
+	if err != nil {
+		return 0, err
+	}
+	return max(marker.Seq, own), nil
+}
+
+// LastSeq returns the highest sequence number assigned in a channel, or 0 if
+// it has no messages.
+func (s *Store) LastSeq(ctx context.Context, channelID primitive.ObjectID) (int64, error) {
+	return s.lastSeq(ctx, bson.M{"channel_id": channelID})
+}
+
+// lastSeq returns the highest seq among messages matching filter, or 0.
+func (s *Store) lastSeq(ctx context.Context, filter bson.M) (int64, error) {
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "seq", Value: -1}}).
+		SetProjection(bson.M{"seq": 1})
+	var msg models.Message
+	err := s.messages.FindOne(ctx, filter, opts).Decode(&msg)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return msg.Seq, nil
+}
+
+// unreadFilter selects the undeleted messages from others in a channel above
+// the reader's read position. A position of 0 means nothing has been read,
+// including messages from before sequence numbers existed.
+func unreadFilter(channelID primitive.ObjectID, reader string, position int64) bson.M {
+	filter := bson.M{
+		"channel_id": channelID,
+		"author":     bson.M{"$ne": reader},
+		"deleted":    bson.M{"$ne": true},
+	}
+	if position > 0 {
+		filter["seq"] = bson.M{"$gt": position}
+	}
+	return filter
+}
+
+// ListUnread returns up to limit of the reader's unread messages in a
+// channel above position, thread replies included, ordered by seq ascending.
+func (s *Store) ListUnread(ctx context.Context, channelID primitive.ObjectID, reader string, position, limit int64) (messages []models.Message, hasMore bool, err error) {
+	return s.findBySeq(ctx, unreadFilter(channelID, reader, position), limit)
+}
+
+// CountUnread counts the reader's unread messages in a channel above position.
+func (s *Store) CountUnread(ctx context.Context, channelID primitive.ObjectID, reader string, position int64) (int64, error) {
+	return s.messages.CountDocuments(ctx, unreadFilter(channelID, reader, position))
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Ticket and PR references
+// ---------------------------------------------------------------------------
+
+// DefaultRefPatterns match the planning board's ticket numbers (MNS-22), the
+// ticket kinds the team writes by hand (STORY-042, EPIC-001, BUG-7, ...) and
+// pull requests (PR #18).
+var DefaultRefPatterns = []string{
+	`(?i)\b(?:MNS|STORY|EPIC|BUG|TASK|TICKET|INIT|SPIKE)-\d+\b`,
+	`(?i)\bPR ?[#-]\d+\b`,
+}
+
+// refSeparators are the characters NormalizeRef collapses into a dash.
+var refSeparators = regexp.MustCompile(`[\s#-]+`)
+
+// NormalizeRef returns the canonical form of a reference as stored in
+// Message.Refs: upper case, with spaces, "#" and dashes between its parts
+// collapsed into one dash, so "PR #18", "pr#18" and "PR-18" are all "PR-18".
+func NormalizeRef(ref string) string {
+	return refSeparators.ReplaceAllString(strings.ToUpper(strings.TrimSpace(ref)), "-")
+}
+
+// CompileRefPatterns compiles reference patterns for SetRefPatterns,
+// skipping blank ones.
+func CompileRefPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// SetRefPatterns replaces the patterns used to find references in new and
+// edited messages. Messages already stored keep their refs. Call it before
+// the store is in use.
+func (s *Store) SetRefPatterns(patterns []*regexp.Regexp) {
+	s.refPatterns = patterns
+}
+
+// ExtractRefs returns the normalized references in content, each once, in
+// order of first appearance.
+func (s *Store) ExtractRefs(content string) []string {
+	type match struct {
+		at  int
+		ref string
+	}
+	var matches []match
+	for _, re := range s.refPatterns {
+		for _, loc := range re.FindAllStringIndex(content, -1) {
+			matches = append(matches, match{loc[0], NormalizeRef(content[loc[0]:loc[1]])})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].at < matches[j].at })
+
+	refs := []string{}
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if m.ref != "" && !seen[m.ref] {
+			seen[m.ref] = true
+			refs = append(refs, m.ref)
+		}
+	}
+	return refs
+}
+
+// BackfillRefs indexes the references in messages stored before refs were
+// extracted, returning how many messages it updated.
+func (s *Store) BackfillRefs(ctx context.Context) (int64, error) {
+	filter := bson.M{"refs": bson.M{"$exists": false}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "content": 1})
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var n int64
+	for cursor.Next(ctx) {
+		var m models.Message
+		if err := cursor.Decode(&m); err != nil {
+			return n, err
+		}
+		_, err := s.messages.UpdateOne(ctx, bson.M{"_id": m.ID}, bson.M{"$set": bson.M{"refs": s.ExtractRefs(m.Content)}})
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, cursor.Err()
+}
+
+// ListRefMessages returns a page of the undeleted messages in the given
+// channels that reference ref (normalized), oldest first.
+func (s *Store) ListRefMessages(ctx context.Context, ref string, channels []primitive.ObjectID, page Page) ([]models.Message, PageInfo, error) {
+	filter := bson.M{
+		"refs":       NormalizeRef(ref),
+		"channel_id": bson.M{"$in": channels},
+		"deleted":    bson.M{"$ne": true},
+	}
+	return findPage(ctx, s.messages, filter, "created_at", page, true, messageCursor)
+}
+
+// ListThreadSummaries returns the threads rooted at the given messages that
+// have at least one reply, oldest root first, with their reply statistics.
+func (s *Store) ListThreadSummaries(ctx context.Context, rootIDs []primitive.ObjectID) ([]models.ThreadSummary, error) {
+	threads := []models.ThreadSummary{}
+	if len(rootIDs) == 0 {
+		return threads, nil
+	}
+	stats, err := s.threadStats(ctx, bson.M{"thread_id": bson.M{"$in": rootIDs}})
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return threads, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(stats))
+	for id := range stats {
+		ids = append(ids, id)
+	}
+	roots, err := s.GetMessagesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].CreatedAt.Before(roots[j].CreatedAt) })
+	for _, root := range roots {
+		threads = append(threads, summarizeThread(root, stats[root.ID]))
+	}
+	return threads, nil
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Retention
+// ---------------------------------------------------------------------------
+
+// ArchiveExpired moves the threads in ch that fall outside policy, along
+// with their mention inbox items, into cold storage tagged with sweepID.
+// Retention counts and ages top-level messages only; an expired root is
+// moved together with all of its replies, so threads are never split.
+// Threads with a pinned message are never moved. It returns the number of
+// messages moved.
+func (s *Store) ArchiveExpired(ctx context.Context, ch *models.Channel, policy models.RetentionPolicy, sweepID primitive.ObjectID, now time.Time) (int64, error) {
+	if !policy.Expires() {
+		return 0, nil
+	}
+
+	var expired []bson.M
+	if policy.MaxAgeDays > 0 {
+		cutoff := now.AddDate(0, 0, -policy.MaxAgeDays)
+		expired = append(expired, bson.M{"created_at": bson.M{"$lt": cutoff}})
+	}
+	if policy.MaxCount > 0 {
+		oldest, err := s.oldestRetained(ctx, ch.ID, policy.MaxCount)
+		if err != nil {
+			return 0, err
+		}
+		if oldest != nil {
+			expired = append(expired, cursorRange("created_at", oldest, "$lt"))
+		}
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	rootFilter := bson.M{"channel_id": ch.ID, "thread_id": nil, "$or": expired}
+	if len(ch.Pins) > 0 {
+		// Pinned messages, and the threads they belong to, stay live
+		// regardless of age.
+		kept, err := s.pinnedThreads(ctx, ch)
+		if err != nil {
+			return 0, err
+		}
+		rootFilter["_id"] = bson.M{"$nin": kept}
+	}
+	roots, err := s.messages.Distinct(ctx, "_id", rootFilter)
+	if err != nil || len(roots) == 0 {
+		return 0, err
+	}
+
+	// channel_id is already set on every document; repeating it in the tag
+	// scopes moveDocuments' post-merge lookup to this channel.
+	tag := bson.M{"sweep_id": sweepID, "archived_at": now, "channel_id": ch.ID}
+	filter := bson.M{"channel_id": ch.ID, "$or": bson.A{
+		bson.M{"_id": bson.M{"$in": roots}},
+		bson.M{"thread_id": bson.M{"$in": roots}},
+	}}
+	count, err := s.moveDocuments(ctx, s.messages, s.coldMessages, filter, tag)
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	ids, err := s.coldMessages.Distinct(ctx, "_id", tag)
+	if err != nil {
+		return count, err
+	}
+	mentionFilter := bson.M{"channel_id": ch.ID, "message_id": bson.M{"$in": ids}}
+	if _, err := s.moveDocuments(ctx, s.mentions, s.coldMentions, mentionFilter, tag); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// pinnedThreads returns the IDs of ch's pinned messages and of the threads
+// they belong to.
+func (s *Store) pinnedThreads(ctx context.Context, ch *models.Channel) ([]any, error) {
+	pinned := make([]primitive.ObjectID, len(ch.Pins))
+	kept := make([]any, len(ch.Pins))
+	for i, p := range ch.Pins {
+		pinned[i] = p.MessageID
+		kept[i] = p.MessageID
+	}
+	roots, err := s.messages.Distinct(ctx, "thread_id", bson.M{"_id": bson.M{"$in": pinned}})
+	if err != nil {
+		return nil, err
+	}
+	return append(kept, roots...), nil
+}
+
+// oldestRetained returns the position of the oldest of the newest n
+// top-level messages in a channel, or nil if the channel has no more than n.
+func (s *Store) oldestRetained(ctx context.Context, channelID primitive.ObjectID, n int) (*Cursor, error) {
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(n - 1)).
+		SetProjection(bson.M{"_id": 1, "created_at": 1})
+
+	var msg models.Message
+	err := s.messages.FindOne(ctx, bson.M{"channel_id": channelID, "thread_id": nil}, opts).Decode(&msg)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{Time: msg.CreatedAt, ID: msg.ID}, nil
+}
+
+// PurgeExpiredClears permanently deletes the archived documents of clear
+// operations whose retention window has passed without a restore, marks the
+// operations purged and returns them.
+func (s *Store) PurgeExpiredClears(ctx context.Context, now time.Time) ([]models.ClearOperation, error) {
+	filter := bson.M{
+		"expires_at":  bson.M{"$lt": now},
+		"restored_at": nil,
+		"purged_at":   nil,
+	}
+	cursor, err := s.clears.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ops []models.ClearOperation
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, err
+	}
+
+	purged := make([]models.ClearOperation, 0, len(ops))
+	for _, op := range ops {
+		archived := bson.M{"clear_id": op.ID}
+		if _, err := s.archivedMessages.DeleteMany(ctx, archived); err != nil {
+			return purged, err
+		}
+		if _, err := s.archivedMentions.DeleteMany(ctx, archived); err != nil {
+			return purged, err
+		}
+		if _, err := s.clears.UpdateOne(ctx, bson.M{"_id": op.ID}, bson.M{"$set": bson.M{"purged_at": now}}); err != nil {
+			return purged, err
+		}
+		op.PurgedAt = &now
+		purged = append(purged, op)
+	}
+	return purged, nil
+}
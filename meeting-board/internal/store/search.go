@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Search operations
+// ---------------------------------------------------------------------------
+
+// SearchQuery describes a message search. Text is matched against the text
+// index on content; every other field is an optional structured filter.
+type SearchQuery struct {
+	Text       string
+	ChannelIDs []primitive.ObjectID // Restrict to these channels; empty matches all.
+	Author     string
+	Mention    string
+	ThreadID   *primitive.ObjectID // Matches the thread root and its replies.
+	From       *time.Time          // Inclusive lower bound on created_at.
+	To         *time.Time          // Exclusive upper bound on created_at.
+	Offset     int64
+	Limit      int64
+}
+
+// SearchResult is a message matched by SearchMessages with its text relevance
+// score (zero when the query has no text).
+type SearchResult struct {
+	models.Message `bson:",inline"`
+	Score          float64 `json:"score" bson:"score"`
+}
+
+// SearchMessages runs a message search. Text searches are ordered by
+// relevance, then newest first; structured-only searches newest first.
+func (s *Store) SearchMessages(ctx context.Context, q SearchQuery) ([]SearchResult, error) {
+	filter := bson.M{}
+	if q.Text != "" {
+		filter["$text"] = bson.M{"$search": q.Text}
+	}
+	if len(q.ChannelIDs) > 0 {
+		filter["channel_id"] = bson.M{"$in": q.ChannelIDs}
+	}
+	if q.Author != "" {
+		filter["author"] = q.Author
+	}
+	if q.Mention != "" {
+		filter["mentions"] = q.Mention
+	}
+	if q.ThreadID != nil {
+		filter["$or"] = []bson.M{
+			{"_id": *q.ThreadID},
+			{"thread_id": *q.ThreadID},
+		}
+	}
+	if q.From != nil || q.To != nil {
+		created := bson.M{}
+		if q.From != nil {
+			created["$gte"] = *q.From
+		}
+		if q.To != nil {
+			created["$lt"] = *q.To
+		}
+		filter["created_at"] = created
+	}
+
+	opts := options.Find().SetSkip(q.Offset).SetLimit(q.Limit)
+	if q.Text != "" {
+		score := bson.M{"$meta": "textScore"}
+		opts.SetProjection(bson.M{"score": score})
+		opts.SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}})
+	} else {
+		opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	}
+
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []SearchResult
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	return results, nil
+}
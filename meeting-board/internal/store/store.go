@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"log"
+	"regexp"
 	"time"
 
 	"github.com/devteam/meeting-board/internal/models"
@@ -16,7 +18,35 @@ type Store struct {
 	db       *mongo.Database
 	channels *mongo.Collection
 	messages *mongo.Collection
+	mentions *mongo.Collection
+	counters *mongo.Collection
 	audit    *mongo.Collection
+	reads    *mongo.Collection
+
+	// Cached conversation summaries.
+	summaries *mongo.Collection
+
+	// Patterns for the ticket and PR references indexed in Message.Refs.
+	refPatterns []*regexp.Regexp
+
+	// Canonical ticket discussion threads and their comment sync state.
+	ticketThreads *mongo.Collection
+
+	// Outbound webhook subscriptions and their delivery log.
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+
+	// Per-channel incoming webhooks.
+	incomingHooks *mongo.Collection
+
+	// Channel clears: operation records plus the archived documents.
+	clears           *mongo.Collection
+	archivedMessages *mongo.Collection
+	archivedMentions *mongo.Collection
+
+	// Cold storage for messages moved out by retention sweeps.
+	coldMessages *mongo.Collection
+	coldMentions *mongo.Collection
 }
 
 // NewStore creates a new Store and ensures required indexes exist.
@@ -25,8 +55,28 @@ func NewStore(db *mongo.Database) *Store {
 		db:       db,
 		channels: db.Collection("channels"),
 		messages: db.Collection("messages"),
+		mentions: db.Collection("mentions"),
+		counters: db.Collection("counters"),
 		audit:    db.Collection("audit"),
+		reads:    db.Collection("read_markers"),
+
+		summaries: db.Collection("summaries"),
+
+		ticketThreads: db.Collection("ticket_threads"),
+
+		webhooks:   db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+
+		incomingHooks: db.Collection("incoming_hooks"),
+
+		clears:           db.Collection("clears"),
+		archivedMessages: db.Collection("archived_messages"),
+		archivedMentions: db.Collection("archived_mentions"),
+
+		coldMessages: db.Collection("cold_messages"),
+		coldMentions: db.Collection("cold_mentions"),
 	}
+	s.refPatterns, _ = CompileRefPatterns(DefaultRefPatterns)
 	s.ensureIndexes()
 	return s
 }
@@ -44,6 +94,32 @@ func (s *Store) ensureIndexes() {
 		},
 	})
 
+	// Unique index on messages: channel_id + seq for gap-free delivery.
+	// Messages from before sequence numbers existed have seq 0 and are excluded.
+	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "channel_id", Value: 1},
+			{Key: "seq", Value: 1},
+		},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"seq": bson.M{"$gt": 0}}),
+	})
+
+	// Index on messages.created_at for board-wide "latest activity" lookups.
+	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "created_at", Value: -1},
+		},
+	})
+
+	// Text index on messages.content for full-text search.
+	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "content", Value: "text"},
+		},
+	})
+
 	// Index on messages.mentions for fast mention lookups.
 	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{
@@ -51,6 +127,14 @@ func (s *Store) ensureIndexes() {
 		},
 	})
 
+	// Index on messages.refs + created_at for per-ticket discussion views.
+	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "refs", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	})
+
 	// Index on messages.thread_id for thread queries.
 	s.messages.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{
@@ -58,6 +142,113 @@ func (s *Store) ensureIndexes() {
 		},
 	})
 
+	// Compound index on the mention inbox: recipient + responded + created_at
+	// for "what is waiting for me" queries.
+	s.mentions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "recipient", Value: 1},
+			{Key: "responded", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	})
+
+	// Compound index on the mention inbox: channel_id + thread_id for marking
+	// mentions responded when a reply arrives.
+	s.mentions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "channel_id", Value: 1},
+			{Key: "thread_id", Value: 1},
+		},
+	})
+
+	// Unique index on read markers: one marker per reader and channel.
+	s.reads.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "reader", Value: 1},
+			{Key: "channel_id", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+
+	// Unique index on summaries: one cached summary per scope.
+	s.summaries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "scope", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	// Unique index on ticket_threads.ref: one canonical thread per ticket.
+	s.ticketThreads.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "ref", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	// Index on ticket_threads.root_id for recording replies as activity.
+	s.ticketThreads.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "root_id", Value: 1}},
+	})
+
+	// Index on webhook_deliveries: status + next_attempt_at for claiming
+	// retries that are due.
+	s.deliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "next_attempt_at", Value: 1},
+		},
+	})
+
+	// Index on webhook_deliveries: webhook_id + created_at for a webhook's
+	// delivery log.
+	s.deliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "webhook_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+
+	// Unique index on incoming_hooks.token: the token alone finds the hook.
+	s.incomingHooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	// Index on incoming_hooks.channel_id for listing a channel's hooks.
+	s.incomingHooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "channel_id", Value: 1}},
+	})
+
+	// Index on clears: channel_id + created_at for listing a channel's clears.
+	s.clears.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "channel_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	})
+
+	// Index on the archives' clear_id for restoring a clear operation.
+	for _, coll := range []*mongo.Collection{s.archivedMessages, s.archivedMentions} {
+		coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "clear_id", Value: 1},
+			},
+		})
+	}
+
+	// Index on cold storage: channel_id + created_at for reading a channel's
+	// history, and sweep_id for tracing what a sweep moved.
+	for _, coll := range []*mongo.Collection{s.coldMessages, s.coldMentions} {
+		coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "channel_id", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+		})
+		coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "sweep_id", Value: 1},
+			},
+		})
+	}
+
 	// Index on audit.timestamp for time-range queries on the audit log.
 	s.audit.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{
@@ -97,9 +288,12 @@ func (s *Store) ListChannels(ctx context.Context) ([]models.Channel, error) {
 	return channels, nil
 }
 
-// CreateChannel inserts a new channel.
+// CreateChannel inserts a new channel. Visibility defaults to public.
 func (s *Store) CreateChannel(ctx context.Context, ch *models.Channel) error {
 	ch.CreatedAt = time.Now().UTC()
+	if ch.Visibility == "" {
+		ch.Visibility = models.ChannelPublic
+	}
 	res, err := s.channels.InsertOne(ctx, ch)
 	if err != nil {
 		return err
@@ -128,74 +322,183 @@ func (s *Store) GetChannelByName(ctx context.Context, name string) (*models.Chan
 	return &ch, nil
 }
 
+// UpdateChannel applies the given field updates to a channel and returns the
+// updated document.
+func (s *Store) UpdateChannel(ctx context.Context, id primitive.ObjectID, set bson.M) (*models.Channel, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var ch models.Channel
+	err := s.channels.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, opts).Decode(&ch)
+	if err != nil {
+		return nil, err
+	}
+	return &ch, nil
+}
+
+// SetChannelArchived archives or reopens a channel and returns the updated document.
+func (s *Store) SetChannelArchived(ctx context.Context, id primitive.ObjectID, archived bool) (*models.Channel, error) {
+	set := bson.M{"archived": archived, "archived_at": nil}
+	if archived {
+		set["archived_at"] = time.Now().UTC()
+	}
+	return s.UpdateChannel(ctx, id, set)
+}
+
 // ---------------------------------------------------------------------------
 // Message operations
 // ---------------------------------------------------------------------------
 
-// ListMessages returns messages for a channel, optionally filtered by a "since" timestamp,
-// with a configurable limit. Results are ordered by created_at ascending.
-func (s *Store) ListMessages(ctx context.Context, channelID primitive.ObjectID, since *time.Time, limit int64) ([]models.Message, error) {
+// ListMessages returns a page of top-level messages for a channel, ordered
+// by created_at ascending, optionally restricted to one author. See Page for
+// cursor semantics.
+func (s *Store) ListMessages(ctx context.Context, channelID primitive.ObjectID, author string, page Page) ([]models.Message, PageInfo, error) {
 	filter := bson.M{"channel_id": channelID, "thread_id": nil}
-	if since != nil {
-		filter["created_at"] = bson.M{"$gt": *since}
+	if author != "" {
+		filter["author"] = author
 	}
+	return findPage(ctx, s.messages, filter, "created_at", page, true, messageCursor)
+}
 
+// ListMessagesAfterSeq returns up to limit messages in a channel with a
+// sequence number greater than afterSeq, thread replies included, ordered by
+// seq ascending. hasMore reports whether further messages follow.
+func (s *Store) ListMessagesAfterSeq(ctx context.Context, channelID primitive.ObjectID, afterSeq, limit int64) (messages []models.Message, hasMore bool, err error) {
+	filter := bson.M{"channel_id": channelID, "seq": bson.M{"$gt": afterSeq}}
+	return s.findBySeq(ctx, filter, limit)
+}
+
+// findBySeq returns up to limit messages matching filter ordered by seq
+// ascending, and whether further messages follow.
+func (s *Store) findBySeq(ctx context.Context, filter bson.M, limit int64) (messages []models.Message, hasMore bool, err error) {
 	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: 1}}).
-		SetLimit(limit)
+		SetSort(bson.D{{Key: "seq", Value: 1}}).
+		SetLimit(limit + 1)
 
 	cursor, err := s.messages.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer cursor.Close(ctx)
 
-	var messages []models.Message
 	if err := cursor.All(ctx, &messages); err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if int64(len(messages)) > limit {
+		messages, hasMore = messages[:limit], true
 	}
 	if messages == nil {
 		messages = []models.Message{}
 	}
-	return messages, nil
+	return messages, hasMore, nil
 }
 
-// CreateMessage inserts a new message into the messages collection.
+// nextSeq atomically increments and returns the named counter, creating it
+// at 1 on first use.
+func (s *Store) nextSeq(ctx context.Context, name string) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := s.counters.FindOneAndUpdate(ctx, bson.M{"_id": name}, bson.M{"$inc": bson.M{"seq": int64(1)}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+// messageCursor returns the pagination position of a message.
+func messageCursor(m *models.Message) Cursor {
+	return Cursor{Time: m.CreatedAt, ID: m.ID}
+}
+
+// CreateMessage inserts a new message into the messages collection and
+// updates the mention inbox: mentions the author was waiting to answer are
+// marked responded, and an inbox item is recorded for each new mention.
+// Inbox failures are logged rather than returned, since the message itself
+// has already been stored.
 func (s *Store) CreateMessage(ctx context.Context, msg *models.Message) error {
+	seq, err := s.nextSeq(ctx, "messages:"+msg.ChannelID.Hex())
+	if err != nil {
+		return err
+	}
+	msg.Seq = seq
 	msg.CreatedAt = time.Now().UTC()
 	if msg.Mentions == nil {
 		msg.Mentions = []string{}
 	}
+	msg.Refs = s.ExtractRefs(msg.Content)
 	res, err := s.messages.InsertOne(ctx, msg)
 	if err != nil {
 		return err
 	}
 	msg.ID = res.InsertedID.(primitive.ObjectID)
+
+	if err := s.markMentionsResponded(ctx, msg); err != nil {
+		log.Printf("store: mark mentions responded for %s: %v", msg.ID.Hex(), err)
+	}
+	if err := s.recordMentions(ctx, msg); err != nil {
+		log.Printf("store: record mentions for %s: %v", msg.ID.Hex(), err)
+	}
 	return nil
 }
 
-// DeleteChannelMessages removes all messages in a channel.
-// Returns the number of deleted messages.
-func (s *Store) DeleteChannelMessages(ctx context.Context, channelID primitive.ObjectID) (int64, error) {
-	result, err := s.messages.DeleteMany(ctx, bson.M{"channel_id": channelID})
+// GetMessageByID retrieves a single message by its ObjectID.
+func (s *Store) GetMessageByID(ctx context.Context, id primitive.ObjectID) (*models.Message, error) {
+	var msg models.Message
+	err := s.messages.FindOne(ctx, bson.M{"_id": id}).Decode(&msg)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return result.DeletedCount, nil
+	return &msg, nil
 }
 
-// ListThreadMessages returns all messages in a given thread, ordered by created_at ascending.
-func (s *Store) ListThreadMessages(ctx context.Context, threadID primitive.ObjectID) ([]models.Message, error) {
-	// Include the root message and all replies.
-	filter := bson.M{
-		"$or": []bson.M{
-			{"_id": threadID},
-			{"thread_id": threadID},
-		},
+// GetMessageBySeq retrieves the message with the given sequence number in a
+// channel.
+func (s *Store) GetMessageBySeq(ctx context.Context, channelID primitive.ObjectID, seq int64) (*models.Message, error) {
+	var msg models.Message
+	err := s.messages.FindOne(ctx, bson.M{"channel_id": channelID, "seq": seq}).Decode(&msg)
+	if err != nil {
+		return nil, err
 	}
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return &msg, nil
+}
 
-	cursor, err := s.messages.Find(ctx, filter, opts)
+// MessageSeqs returns the seq of each of the given messages, keyed by ID.
+// Messages that do not exist are left out.
+func (s *Store) MessageSeqs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	seqs := make(map[primitive.ObjectID]int64, len(ids))
+	if len(ids) == 0 {
+		return seqs, nil
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "seq": 1})
+	cursor, err := s.messages.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []models.Message
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	for _, m := range found {
+		seqs[m.ID] = m.Seq
+	}
+	return seqs, nil
+}
+
+// ListThreadReplies returns the replies in a thread (excluding the root),
+// ordered by created_at ascending, skipping offset replies and returning at
+// most limit.
+func (s *Store) ListThreadReplies(ctx context.Context, threadID primitive.ObjectID, offset, limit int64) ([]models.Message, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+
+	cursor, err := s.messages.Find(ctx, bson.M{"thread_id": threadID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -211,13 +514,18 @@ func (s *Store) ListThreadMessages(ctx context.Context, threadID primitive.Objec
 	return messages, nil
 }
 
-// ListThreadRoots returns messages in a channel that have been used as thread roots
-// (i.e., messages that have at least one reply).
-func (s *Store) ListThreadRoots(ctx context.Context, channelID primitive.ObjectID) ([]models.Message, error) {
-	// First find all distinct thread_ids in this channel.
+// threadStats aggregates reply statistics for every thread whose replies
+// match the given filter, keyed by thread root ID.
+func (s *Store) threadStats(ctx context.Context, match bson.M) (map[primitive.ObjectID]*models.ThreadSummary, error) {
+	match["thread_id"] = bson.M{"$ne": nil}
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"channel_id": channelID, "thread_id": bson.M{"$ne": nil}}}},
-		{{Key: "$group", Value: bson.M{"_id": "$thread_id"}}},
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$thread_id",
+			"reply_count":   bson.M{"$sum": 1},
+			"last_reply_at": bson.M{"$max": "$created_at"},
+			"participants":  bson.M{"$addToSet": "$author"},
+		}}},
 	}
 
 	cursor, err := s.messages.Aggregate(ctx, pipeline)
@@ -226,67 +534,86 @@ func (s *Store) ListThreadRoots(ctx context.Context, channelID primitive.ObjectI
 	}
 	defer cursor.Close(ctx)
 
-	var threadIDs []struct {
-		ID primitive.ObjectID `bson:"_id"`
+	var rows []struct {
+		ThreadID     primitive.ObjectID `bson:"_id"`
+		ReplyCount   int64              `bson:"reply_count"`
+		LastReplyAt  time.Time          `bson:"last_reply_at"`
+		Participants []string           `bson:"participants"`
 	}
-	if err := cursor.All(ctx, &threadIDs); err != nil {
+	if err := cursor.All(ctx, &rows); err != nil {
 		return nil, err
 	}
 
-	if len(threadIDs) == 0 {
-		return []models.Message{}, nil
-	}
-
-	ids := make([]primitive.ObjectID, len(threadIDs))
-	for i, t := range threadIDs {
-		ids[i] = t.ID
+	stats := make(map[primitive.ObjectID]*models.ThreadSummary, len(rows))
+	for _, row := range rows {
+		stats[row.ThreadID] = &models.ThreadSummary{
+			ReplyCount:   row.ReplyCount,
+			LastReplyAt:  &row.LastReplyAt,
+			Participants: row.Participants,
+		}
 	}
+	return stats, nil
+}
 
-	filter := bson.M{"_id": bson.M{"$in": ids}}
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+// summarizeThread combines a root message with its reply statistics. The
+// root author is listed first among the participants.
+func summarizeThread(root models.Message, st *models.ThreadSummary) models.ThreadSummary {
+	summary := models.ThreadSummary{Message: root, Participants: []string{root.Author}}
+	if st == nil {
+		return summary
+	}
+	summary.ReplyCount = st.ReplyCount
+	summary.LastReplyAt = st.LastReplyAt
+	for _, p := range st.Participants {
+		if p != root.Author {
+			summary.Participants = append(summary.Participants, p)
+		}
+	}
+	return summary
+}
 
-	rootCursor, err := s.messages.Find(ctx, filter, opts)
+// GetThreadSummary returns the root message of a thread with its reply statistics.
+func (s *Store) GetThreadSummary(ctx context.Context, rootID primitive.ObjectID) (*models.ThreadSummary, error) {
+	root, err := s.GetMessageByID(ctx, rootID)
 	if err != nil {
 		return nil, err
 	}
-	defer rootCursor.Close(ctx)
-
-	var roots []models.Message
-	if err := rootCursor.All(ctx, &roots); err != nil {
+	stats, err := s.threadStats(ctx, bson.M{"thread_id": rootID})
+	if err != nil {
 		return nil, err
 	}
-	if roots == nil {
-		roots = []models.Message{}
-	}
-	return roots, nil
+	summary := summarizeThread(*root, stats[rootID])
+	return &summary, nil
 }
 
-// ---------------------------------------------------------------------------
-// Mention operations
-// ---------------------------------------------------------------------------
+// ListThreadRoots returns a page of messages in a channel that have been used
+// as thread roots (i.e., messages that have at least one reply), newest first,
+// each with its reply count, last reply time and participants.
+func (s *Store) ListThreadRoots(ctx context.Context, channelID primitive.ObjectID, page Page) ([]models.ThreadSummary, PageInfo, error) {
+	stats, err := s.threadStats(ctx, bson.M{"channel_id": channelID})
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if len(stats) == 0 {
+		return []models.ThreadSummary{}, PageInfo{}, nil
+	}
 
-// GetMentionsSince returns messages that mention the given role since the provided timestamp.
-func (s *Store) GetMentionsSince(ctx context.Context, role string, since time.Time) ([]models.Message, error) {
-	filter := bson.M{
-		"mentions":   role,
-		"created_at": bson.M{"$gt": since},
+	ids := make([]primitive.ObjectID, 0, len(stats))
+	for id := range stats {
+		ids = append(ids, id)
 	}
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
 
-	cursor, err := s.messages.Find(ctx, filter, opts)
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	roots, info, err := findPage(ctx, s.messages, filter, "created_at", page, false, messageCursor)
 	if err != nil {
-		return nil, err
+		return nil, PageInfo{}, err
 	}
-	defer cursor.Close(ctx)
 
-	var messages []models.Message
-	if err := cursor.All(ctx, &messages); err != nil {
-		return nil, err
+	summaries := make([]models.ThreadSummary, len(roots))
+	for i, root := range roots {
+		summaries[i] = summarizeThread(root, stats[root.ID])
 	}
-	if messages == nil {
-		messages = []models.Message{}
-	}
-	return messages, nil
+	return summaries, info, nil
 }
 
 // ---------------------------------------------------------------------------
@@ -304,32 +631,14 @@ func (s *Store) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry)
 	return nil
 }
 
-// ListAuditEntries retrieves audit entries with optional filters for actor and since timestamp.
-func (s *Store) ListAuditEntries(ctx context.Context, actor string, since *time.Time, limit int64) ([]models.AuditEntry, error) {
+// ListAuditEntries retrieves a page of audit entries, newest first, with an
+// optional filter for actor.
+func (s *Store) ListAuditEntries(ctx context.Context, actor string, page Page) ([]models.AuditEntry, PageInfo, error) {
 	filter := bson.M{}
 	if actor != "" {
 		filter["actor"] = actor
 	}
-	if since != nil {
-		filter["timestamp"] = bson.M{"$gt": *since}
-	}
-
-	opts := options.Find().
-		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
-		SetLimit(limit)
-
-	cursor, err := s.audit.Find(ctx, filter, opts)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var entries []models.AuditEntry
-	if err := cursor.All(ctx, &entries); err != nil {
-		return nil, err
-	}
-	if entries == nil {
-		entries = []models.AuditEntry{}
-	}
-	return entries, nil
+	return findPage(ctx, s.audit, filter, "timestamp", page, false, func(e *models.AuditEntry) Cursor {
+		return Cursor{Time: e.Timestamp, ID: e.ID}
+	})
 }
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Summary cache
+// ---------------------------------------------------------------------------
+
+// GetSummary returns the cached summary for scope, or mongo.ErrNoDocuments.
+func (s *Store) GetSummary(ctx context.Context, scope string) (*models.ConversationSummary, error) {
+	var sum models.ConversationSummary
+	if err := s.summaries.FindOne(ctx, bson.M{"scope": scope}).Decode(&sum); err != nil {
+		return nil, err
+	}
+	return &sum, nil
+}
+
+// SaveSummary stores sum as the cached summary for its scope, replacing any
+// previous one.
+func (s *Store) SaveSummary(ctx context.Context, sum *models.ConversationSummary) error {
+	sum.ID = primitive.NilObjectID
+	_, err := s.summaries.ReplaceOne(ctx, bson.M{"scope": sum.Scope}, sum, options.Replace().SetUpsert(true))
+	return err
+}
+
+// ListMessagesSince returns the newest limit undeleted messages in a channel
+// created after since, thread replies included, in seq order. hasMore
+// reports whether older messages in the range were left out.
+func (s *Store) ListMessagesSince(ctx context.Context, channelID primitive.ObjectID, since time.Time, limit int64) (messages []models.Message, hasMore bool, err error) {
+	filter := bson.M{
+		"channel_id": channelID,
+		"deleted":    bson.M{"$ne": true},
+		"created_at": bson.M{"$gt": since},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: -1}}).SetLimit(limit + 1)
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, false, err
+	}
+	if int64(len(messages)) > limit {
+		messages, hasMore = messages[:limit], true
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, hasMore, nil
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Ticket discussion threads
+// ---------------------------------------------------------------------------
+
+// IsTicketRef reports whether a normalized reference names a ticket rather
+// than a pull request.
+func IsTicketRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "PR-")
+}
+
+// TrackTicketThreads records msg, already stored, as activity on the
+// canonical threads of the tickets it references. A ticket seen for the
+// first time gets msg's thread (msg itself, for a top-level message) as its
+// canonical thread. References to tickets whose thread is elsewhere are
+// linked from msg and returned. A reply also counts as activity on every
+// ticket thread it belongs to. The caller decides which channels take part.
+func (s *Store) TrackTicketThreads(ctx context.Context, msg *models.Message) ([]models.TicketThreadLink, error) {
+	root := msg.ID
+	if msg.ThreadID != nil {
+		root = *msg.ThreadID
+		_, err := s.ticketThreads.UpdateMany(ctx,
+			bson.M{"root_id": root},
+			bson.M{"$max": bson.M{"last_activity_at": msg.CreatedAt}},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var links []models.TicketThreadLink
+	for _, ref := range msg.Refs {
+		if !IsTicketRef(ref) {
+			continue
+		}
+		tt, err := s.claimTicketThread(ctx, ref, msg.ChannelID, root, msg.CreatedAt)
+		if err != nil {
+			return links, err
+		}
+		if tt.RootID != root {
+			links = append(links, models.TicketThreadLink{Ref: ref, ChannelID: tt.ChannelID, ThreadID: tt.RootID})
+		}
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	_, err := s.messages.UpdateOne(ctx, bson.M{"_id": msg.ID}, bson.M{"$set": bson.M{"ticket_threads": links}})
+	if err != nil {
+		return nil, err
+	}
+	msg.TicketThreads = links
+	return links, nil
+}
+
+// claimTicketThread returns ref's canonical thread, making the thread rooted
+// at root in channelID canonical if ref has none yet, and records activity
+// at the given time.
+func (s *Store) claimTicketThread(ctx context.Context, ref string, channelID, root primitive.ObjectID, at time.Time) (*models.TicketThread, error) {
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"channel_id": channelID,
+			"root_id":    root,
+			"created_at": at,
+			"comments":   0,
+		},
+		"$max": bson.M{"last_activity_at": at},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var tt models.TicketThread
+	err := s.ticketThreads.FindOneAndUpdate(ctx, bson.M{"ref": ref}, update, opts).Decode(&tt)
+	if mongo.IsDuplicateKeyError(err) {
+		// Another message claimed the ticket concurrently; retry as an update.
+		err = s.ticketThreads.FindOneAndUpdate(ctx, bson.M{"ref": ref}, update, opts).Decode(&tt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tt, nil
+}
+
+// GetTicketThread returns the canonical thread of ref (normalized), or
+// mongo.ErrNoDocuments.
+func (s *Store) GetTicketThread(ctx context.Context, ref string) (*models.TicketThread, error) {
+	var tt models.TicketThread
+	if err := s.ticketThreads.FindOne(ctx, bson.M{"ref": NormalizeRef(ref)}).Decode(&tt); err != nil {
+		return nil, err
+	}
+	return &tt, nil
+}
+
+// ListTicketThreadsToSync returns the ticket threads with activity since
+// their last sync that have been quiet since idleSince and are not backing
+// off at now, least recently active first.
+func (s *Store) ListTicketThreadsToSync(ctx context.Context, idleSince, now time.Time) ([]models.TicketThread, error) {
+	filter := bson.M{
+		"last_activity_at": bson.M{"$lte": idleSince},
+		"$expr": bson.M{"$gt": bson.A{
+			"$last_activity_at",
+			bson.M{"$ifNull": bson.A{"$synced_through", time.Time{}}},
+		}},
+		"$or": bson.A{
+			bson.M{"next_attempt_at": bson.M{"$exists": false}},
+			bson.M{"next_attempt_at": bson.M{"$lte": now}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_activity_at", Value: 1}})
+	cursor, err := s.ticketThreads.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	threads := []models.TicketThread{}
+	if err := cursor.All(ctx, &threads); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+// ListTicketDiscussion returns the newest limit undeleted messages created
+// after since and no later than through that make up tt's discussion: its
+// canonical thread plus every other message referencing the ticket, oldest
+// first. The caller filters out channels that must not be synced.
+func (s *Store) ListTicketDiscussion(ctx context.Context, tt *models.TicketThread, since, through time.Time, limit int64) ([]models.Message, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"_id": tt.RootID},
+			bson.M{"thread_id": tt.RootID},
+			bson.M{"refs": tt.Ref},
+		},
+		"deleted":    bson.M{"$ne": true},
+		"created_at": bson.M{"$gt": since, "$lte": through},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit)
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []models.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+// MarkTicketThreadSynced records that tt's discussion up to through has been
+// synced, counting a posted comment if commented, and clears any backoff.
+func (s *Store) MarkTicketThreadSynced(ctx context.Context, id primitive.ObjectID, through time.Time, commented bool) error {
+	update := bson.M{
+		"$set":   bson.M{"synced_through": through},
+		"$unset": bson.M{"attempts": "", "next_attempt_at": "", "last_error": ""},
+	}
+	if commented {
+		update["$inc"] = bson.M{"comments": 1}
+	}
+	_, err := s.ticketThreads.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// MarkTicketThreadFailed records a failed sync of a ticket thread and when
+// to try again.
+func (s *Store) MarkTicketThreadFailed(ctx context.Context, id primitive.ObjectID, reason string, next time.Time) error {
+	_, err := s.ticketThreads.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"last_error": reason, "next_attempt_at": next},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
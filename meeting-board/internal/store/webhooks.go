@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Webhooks
+// ---------------------------------------------------------------------------
+
+// CreateWebhook inserts a webhook subscription.
+func (s *Store) CreateWebhook(ctx context.Context, hook *models.Webhook) error {
+	hook.CreatedAt = time.Now().UTC()
+	res, err := s.webhooks.InsertOne(ctx, hook)
+	if err != nil {
+		return err
+	}
+	hook.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetWebhook returns a webhook by ID, or mongo.ErrNoDocuments.
+func (s *Store) GetWebhook(ctx context.Context, id primitive.ObjectID) (*models.Webhook, error) {
+	var hook models.Webhook
+	if err := s.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// ListWebhooks returns every webhook, oldest first.
+func (s *Store) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{})
+}
+
+// MatchWebhooks returns the webhooks subscribed to an event type in a
+// channel. Mention filters are left to the caller.
+func (s *Store) MatchWebhooks(ctx context.Context, event string, channelID primitive.ObjectID) ([]models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{
+		"$and": bson.A{
+			bson.M{"$or": bson.A{bson.M{"events": bson.M{"$size": 0}}, bson.M{"events": event}}},
+			bson.M{"$or": bson.A{bson.M{"channel_ids": bson.M{"$size": 0}}, bson.M{"channel_ids": channelID}}},
+		},
+	})
+}
+
+// findWebhooks returns the webhooks matching filter, oldest first.
+func (s *Store) findWebhooks(ctx context.Context, filter bson.M) ([]models.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := s.webhooks.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hooks := []models.Webhook{}
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// DeleteWebhook removes a webhook and dead-letters its pending deliveries.
+// It returns mongo.ErrNoDocuments if there is no such webhook.
+func (s *Store) DeleteWebhook(ctx context.Context, id primitive.ObjectID) error {
+	res, err := s.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	_, err = s.deliveries.UpdateMany(ctx,
+		bson.M{"webhook_id": id, "status": models.DeliveryPending},
+		bson.M{
+			"$set":   bson.M{"status": models.DeliveryDead, "last_error": "webhook deleted"},
+			"$unset": bson.M{"next_attempt_at": ""},
+		},
+	)
+	return err
+}
+
+// ---------------------------------------------------------------------------
+// Webhook deliveries
+// ---------------------------------------------------------------------------
+
+// DeliveryFilter selects webhook deliveries; zero fields match everything.
+type DeliveryFilter struct {
+	WebhookID *primitive.ObjectID
+	Status    string
+}
+
+// CreateDeliveries inserts webhook deliveries, which must already have IDs,
+// in one round trip.
+func (s *Store) CreateDeliveries(ctx context.Context, ds []*models.WebhookDelivery) error {
+	docs := make([]any, len(ds))
+	for i, d := range ds {
+		docs[i] = d
+	}
+	_, err := s.deliveries.InsertMany(ctx, docs)
+	return err
+}
+
+// GetDelivery returns a webhook delivery by ID, or mongo.ErrNoDocuments.
+func (s *Store) GetDelivery(ctx context.Context, id primitive.ObjectID) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	if err := s.deliveries.FindOne(ctx, bson.M{"_id": id}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ClaimDueDelivery returns a pending delivery whose next attempt is due at
+// now, pushing that attempt back by lease so no one else picks it up while
+// it is in flight. It returns mongo.ErrNoDocuments when nothing is due.
+func (s *Store) ClaimDueDelivery(ctx context.Context, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          models.DeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var d models.WebhookDelivery
+	if err := s.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// SaveDeliveryAttempt stores the outcome of a delivery attempt: status,
+// attempts, last status and error, and the next attempt or delivery time.
+func (s *Store) SaveDeliveryAttempt(ctx context.Context, d *models.WebhookDelivery) error {
+	set := bson.M{
+		"status":      d.Status,
+		"attempts":    d.Attempts,
+		"last_status": d.LastStatus,
+		"last_error":  d.LastError,
+	}
+	unset := bson.M{}
+	if d.NextAttemptAt != nil {
+		set["next_attempt_at"] = d.NextAttemptAt
+	} else {
+		unset["next_attempt_at"] = ""
+	}
+	if d.DeliveredAt != nil {
+		set["delivered_at"] = d.DeliveredAt
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	_, err := s.deliveries.UpdateOne(ctx, bson.M{"_id": d.ID}, update)
+	return err
+}
+
+// ResetDelivery makes a delivery pending again with a fresh set of attempts,
+// due immediately, and returns it. It returns mongo.ErrNoDocuments if there
+// is no such delivery.
+func (s *Store) ResetDelivery(ctx context.Context, id primitive.ObjectID) (*models.WebhookDelivery, error) {
+	update := bson.M{
+		"$set":   bson.M{"status": models.DeliveryPending, "attempts": 0, "next_attempt_at": time.Now().UTC()},
+		"$unset": bson.M{"last_error": "", "last_status": "", "delivered_at": ""},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var d models.WebhookDelivery
+	if err := s.deliveries.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListDeliveries returns a page of webhook deliveries, newest first.
+func (s *Store) ListDeliveries(ctx context.Context, f DeliveryFilter, page Page) ([]models.WebhookDelivery, PageInfo, error) {
+	filter := bson.M{}
+	if f.WebhookID != nil {
+		filter["webhook_id"] = *f.WebhookID
+	}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	return findPage(ctx, s.deliveries, filter, "created_at", page, false, func(d *models.WebhookDelivery) Cursor {
+		return Cursor{Time: d.CreatedAt, ID: d.ID}
+	})
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ---------------------------------------------------------------------------
+// Context window queries
+// ---------------------------------------------------------------------------
+
+// RecentMessages returns up to limit undeleted messages in a channel with a
+// seq below beforeSeq, thread replies included, newest first.
+func (s *Store) RecentMessages(ctx context.Context, channelID primitive.ObjectID, beforeSeq, limit int64) ([]models.Message, error) {
+	filter := bson.M{
+		"channel_id": channelID,
+		"deleted":    bson.M{"$ne": true},
+		"seq":        bson.M{"$gt": 0, "$lt": beforeSeq},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: -1}}).SetLimit(limit)
+	cursor, err := s.messages.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []models.Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// CountMessagesBefore counts the undeleted messages in a channel with a seq
+// below beforeSeq, other than those in exclude. Messages from before sequence
+// numbers existed (seq 0) are always counted.
+func (s *Store) CountMessagesBefore(ctx context.Context, channelID primitive.ObjectID, beforeSeq int64, exclude []primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"channel_id": channelID,
+		"deleted":    bson.M{"$ne": true},
+		"seq":        bson.M{"$lt": beforeSeq},
+	}
+	if len(exclude) > 0 {
+		filter["_id"] = bson.M{"$nin": exclude}
+	}
+	return s.messages.CountDocuments(ctx, filter)
+}
+
+// GetMessagesByIDs returns the given messages that exist, in seq order.
+func (s *Store) GetMessagesByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Message, error) {
+	if len(ids) == 0 {
+		return []models.Message{}, nil
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}})
+	cursor, err := s.messages.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []models.Message{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
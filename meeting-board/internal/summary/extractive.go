@@ -0,0 +1,149 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/devteam/meeting-board/internal/models"
+)
+
+// EngineExtractive names the Extractive summarizer in results.
+const EngineExtractive = "extractive"
+
+// decisionWords mark sentences that are likely to carry a decision, an
+// outcome or an action item.
+var decisionWords = []string{
+	"decided", "decision", "agreed", "agree", "approved", "approve", "rejected",
+	"blocked", "blocker", "will ", "todo", "action item", "next step",
+	"merged", "shipped", "fixed", "done", "lgtm",
+}
+
+// signalReactions mark messages whose outcome the team reacted to.
+var signalReactions = []string{models.ReactionApprove, models.ReactionReject, models.ReactionBlocked, models.ReactionDone}
+
+// Extractive summarizes without a model by quoting the conversation: who
+// took part, how it opened, the messages that look like decisions or action
+// items, and where it stands now. Its output depends only on its input.
+type Extractive struct {
+	MaxPoints   int // key points to quote; zero means 5
+	MaxSentence int // characters per quoted sentence; zero means 200
+}
+
+// Summarize implements Summarizer.
+func (e Extractive) Summarize(ctx context.Context, c Conversation) (Result, error) {
+	maxPoints := e.MaxPoints
+	if maxPoints <= 0 {
+		maxPoints = 5
+	}
+	maxSentence := e.MaxSentence
+	if maxSentence <= 0 {
+		maxSentence = 200
+	}
+	res := Result{Engine: EngineExtractive}
+	msgs := c.Messages
+	if len(msgs) == 0 {
+		res.Text = "No messages."
+		return res, nil
+	}
+
+	var b strings.Builder
+	var speakers []string
+	seen := make(map[string]bool)
+	for i := range msgs {
+		if s := speaker(&msgs[i]); !seen[s] {
+			seen[s] = true
+			speakers = append(speakers, s)
+		}
+	}
+	first, last := msgs[0].CreatedAt.UTC(), msgs[len(msgs)-1].CreatedAt.UTC()
+	if c.Title != "" {
+		b.WriteString(c.Title + ": ")
+	}
+	fmt.Fprintf(&b, "%d messages from %s, %s to %s UTC.\n",
+		len(msgs), strings.Join(speakers, ", "),
+		first.Format("2006-01-02 15:04"), last.Format("2006-01-02 15:04"))
+
+	fmt.Fprintf(&b, "Opened by %s: %s\n", speaker(&msgs[0]), firstSentence(msgs[0].Content, maxSentence))
+
+	var points []string
+	for i := 1; i < len(msgs)-1 && len(points) < maxPoints; i++ {
+		if sentence, ok := keySentence(&msgs[i], maxSentence); ok {
+			points = append(points, fmt.Sprintf("- %s: %s", speaker(&msgs[i]), sentence))
+		}
+	}
+	if len(points) > 0 {
+		b.WriteString("Key points:\n")
+		b.WriteString(strings.Join(points, "\n"))
+		b.WriteByte('\n')
+	}
+
+	if len(msgs) > 1 {
+		m := &msgs[len(msgs)-1]
+		fmt.Fprintf(&b, "Latest from %s: %s\n", speaker(m), firstSentence(m.Content, maxSentence))
+	}
+	res.Text = strings.TrimRight(b.String(), "\n")
+	return res, nil
+}
+
+// keySentence returns the sentence of m that looks like a decision or action
+// item, or its first sentence if the team reacted to its outcome.
+func keySentence(m *models.Message, max int) (string, bool) {
+	for _, s := range sentences(m.Content) {
+		lower := strings.ToLower(s)
+		for _, w := range decisionWords {
+			if strings.Contains(lower, w) {
+				return clip(s, max), true
+			}
+		}
+	}
+	for _, r := range signalReactions {
+		if m.ReactionCounts[r] > 0 {
+			return firstSentence(m.Content, max) + " [" + r + "]", true
+		}
+	}
+	return "", false
+}
+
+// firstSentence returns the first sentence of text, clipped to max characters.
+func firstSentence(text string, max int) string {
+	if s := sentences(text); len(s) > 0 {
+		return clip(s[0], max)
+	}
+	return ""
+}
+
+// sentences splits text into trimmed sentences at line breaks and at ". ",
+// "! " and "? ".
+func sentences(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		start := 0
+		for i := 0; i < len(line); i++ {
+			if (line[i] == '.' || line[i] == '!' || line[i] == '?') && i+1 < len(line) && line[i+1] == ' ' {
+				out = appendSentence(out, line[start:i+1])
+				start = i + 1
+			}
+		}
+		out = appendSentence(out, line[start:])
+	}
+	return out
+}
+
+func appendSentence(out []string, s string) []string {
+	s = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(s), "-*#> "))
+	if s == "" {
+		return out
+	}
+	return append(out, s)
+}
+
+// clip shortens s to at most max characters, marking the cut with "…".
+func clip(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
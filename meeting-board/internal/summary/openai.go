@@ -0,0 +1,102 @@
+package summary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EngineOpenAI names the OpenAI summarizer in results.
+const EngineOpenAI = "openai"
+
+// systemPrompt instructs the model how to summarize.
+const systemPrompt = `You summarize conversations between the members of a software team.
+Write plain text, at most 8 short lines: the topic, decisions made, open questions, and action items with their owners.
+Refer to people as they are named in the transcript. Do not invent anything that is not in it.`
+
+// OpenAI summarizes with any server that implements the OpenAI chat
+// completions API, such as a local model behind an OpenAI-compatible proxy.
+type OpenAI struct {
+	BaseURL string // e.g. "http://localhost:11434/v1"; "/chat/completions" is appended
+	APIKey  string // sent as a bearer token if set
+	Model   string
+	Client  *http.Client // nil means a client with a one-minute timeout
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Summarize implements Summarizer.
+func (o OpenAI) Summarize(ctx context.Context, c Conversation) (Result, error) {
+	prompt := transcript(c)
+	if c.Title != "" {
+		prompt = c.Title + "\n\n" + prompt
+	}
+	body, err := json.Marshal(chatRequest{
+		Model: o.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	url := strings.TrimRight(o.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+
+	client := o.Client
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("openai summarizer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Result{}, fmt.Errorf("openai summarizer: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("openai summarizer: %s: %s", resp.Status, clip(string(bytes.TrimSpace(data)), 200))
+	}
+
+	var out chatResponse
+	if err := json.Unmarshal(data, &out); err != nil {
+		return Result{}, fmt.Errorf("openai summarizer: decode response: %w", err)
+	}
+	if len(out.Choices) == 0 || strings.TrimSpace(out.Choices[0].Message.Content) == "" {
+		return Result{}, errors.New("openai summarizer: empty response")
+	}
+	return Result{Text: strings.TrimSpace(out.Choices[0].Message.Content), Engine: EngineOpenAI}, nil
+}
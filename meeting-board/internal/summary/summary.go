@@ -0,0 +1,73 @@
+// Package summary condenses meeting board conversations so agents need not
+// re-read long threads every heartbeat.
+package summary
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/devteam/meeting-board/internal/models"
+)
+
+// Conversation is a run of messages to summarize, oldest first.
+type Conversation struct {
+	Title    string // e.g. "Thread #40 in #review"
+	Messages []models.Message
+}
+
+// Result is a generated summary and the engine that produced it.
+type Result struct {
+	Text   string
+	Engine string
+}
+
+// Summarizer condenses a conversation.
+type Summarizer interface {
+	Summarize(ctx context.Context, c Conversation) (Result, error)
+}
+
+// WithFallback returns a Summarizer that uses primary and, if it fails, logs
+// the error and uses fallback instead.
+func WithFallback(primary, fallback Summarizer) Summarizer {
+	return fallbackSummarizer{primary: primary, fallback: fallback}
+}
+
+type fallbackSummarizer struct {
+	primary, fallback Summarizer
+}
+
+func (f fallbackSummarizer) Summarize(ctx context.Context, c Conversation) (Result, error) {
+	res, err := f.primary.Summarize(ctx, c)
+	if err == nil {
+		return res, nil
+	}
+	log.Printf("summary: %v; falling back", err)
+	return f.fallback.Summarize(ctx, c)
+}
+
+// speaker renders a message's author as "Name(role)".
+func speaker(m *models.Message) string {
+	name := m.AuthorName
+	if name == "" {
+		name = m.Author
+	}
+	if m.AuthorRole == "" || strings.EqualFold(m.AuthorRole, name) {
+		return name
+	}
+	return name + "(" + m.AuthorRole + ")"
+}
+
+// transcript renders c one message per line, as "[15:04] Name(role): text".
+func transcript(c Conversation) string {
+	var b strings.Builder
+	for i := range c.Messages {
+		m := &c.Messages[i]
+		b.WriteString(m.CreatedAt.UTC().Format("[2006-01-02 15:04] "))
+		b.WriteString(speaker(m))
+		b.WriteString(": ")
+		b.WriteString(strings.ReplaceAll(strings.TrimSpace(m.Content), "\n", "\n  "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
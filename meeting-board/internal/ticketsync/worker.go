@@ -0,0 +1,193 @@
+package ticketsync
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/planning"
+	"github.com/devteam/meeting-board/internal/store"
+	"github.com/devteam/meeting-board/internal/summary"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Actor is the audit log actor recorded for ticket syncs.
+const Actor = "ticket-sync"
+
+// maxMessages caps how many new messages go into one ticket comment; a
+// busier discussion is summarized from its newest messages.
+const maxMessages = 200
+
+// maxBackoff caps how long a failing ticket waits between attempts.
+const maxBackoff = 6 * time.Hour
+
+// Worker periodically posts what was said about each ticket since its last
+// sync as a comment on the ticket: a summary of the new messages in its
+// canonical thread and elsewhere in public channels. A discussion is synced
+// once it has been quiet for Quiet, so a busy thread yields one comment
+// rather than one per message. Failed syncs are retried with exponential
+// backoff. Every sync and failure is recorded in the audit log.
+type Worker struct {
+	Store      *store.Store
+	Comments   planning.Commenter
+	Summarizer summary.Summarizer // nil means summary.Extractive
+	Interval   time.Duration
+	Quiet      time.Duration
+}
+
+// Run syncs once immediately and then every Interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.Sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep syncs every ticket discussion that is due.
+func (w *Worker) Sweep(ctx context.Context) {
+	now := time.Now().UTC()
+	threads, err := w.Store.ListTicketThreadsToSync(ctx, now.Add(-w.Quiet), now)
+	if err != nil {
+		log.Printf("ticketsync: list threads: %v", err)
+		return
+	}
+	if len(threads) == 0 {
+		return
+	}
+
+	channels, err := w.Store.ListChannels(ctx)
+	if err != nil {
+		log.Printf("ticketsync: list channels: %v", err)
+		return
+	}
+	public := make(map[primitive.ObjectID]*models.Channel, len(channels))
+	for i := range channels {
+		if !channels[i].IsPrivate() {
+			public[channels[i].ID] = &channels[i]
+		}
+	}
+
+	for i := range threads {
+		w.sync(ctx, &threads[i], public)
+	}
+}
+
+// sync posts one ticket's new discussion and records the outcome.
+func (w *Worker) sync(ctx context.Context, tt *models.TicketThread, public map[primitive.ObjectID]*models.Channel) {
+	var since time.Time
+	if tt.SyncedThrough != nil {
+		since = *tt.SyncedThrough
+	}
+	through := tt.LastActivityAt
+
+	msgs, err := w.Store.ListTicketDiscussion(ctx, tt, since, through, maxMessages)
+	if err != nil {
+		log.Printf("ticketsync: %s: list discussion: %v", tt.Ref, err)
+		return
+	}
+	var shared []models.Message
+	for _, m := range msgs {
+		if public[m.ChannelID] != nil {
+			shared = append(shared, m)
+		}
+	}
+	if len(shared) == 0 {
+		// Everything new was deleted or private; nothing to say.
+		if err := w.Store.MarkTicketThreadSynced(ctx, tt.ID, through, false); err != nil {
+			log.Printf("ticketsync: %s: mark synced: %v", tt.Ref, err)
+		}
+		return
+	}
+
+	comment, engine, err := w.comment(ctx, tt, shared, public)
+	if err == nil {
+		err = w.Comments.AddComment(ctx, tt.Ref, comment)
+	}
+
+	switch {
+	case err == planning.ErrNotFound:
+		// Not a ticket the planning board knows; stop trying until it is
+		// discussed again.
+		if err := w.Store.MarkTicketThreadSynced(ctx, tt.ID, through, false); err != nil {
+			log.Printf("ticketsync: %s: mark synced: %v", tt.Ref, err)
+		}
+		w.audit(ctx, "ticket.sync_skipped", tt, map[string]any{"reason": "ticket not found"})
+
+	case err != nil:
+		attempts := tt.Attempts + 1
+		next := time.Now().UTC().Add(w.backoff(attempts))
+		log.Printf("ticketsync: %s: attempt %d failed, retrying at %s: %v", tt.Ref, attempts, next.Format(time.RFC3339), err)
+		if err := w.Store.MarkTicketThreadFailed(ctx, tt.ID, err.Error(), next); err != nil {
+			log.Printf("ticketsync: %s: mark failed: %v", tt.Ref, err)
+		}
+		w.audit(ctx, "ticket.sync_failed", tt, map[string]any{
+			"attempts":        attempts,
+			"error":           err.Error(),
+			"next_attempt_at": next,
+		})
+
+	default:
+		if err := w.Store.MarkTicketThreadSynced(ctx, tt.ID, through, true); err != nil {
+			log.Printf("ticketsync: %s: mark synced: %v", tt.Ref, err)
+		}
+		w.audit(ctx, "ticket.sync", tt, map[string]any{
+			"messages":       len(shared),
+			"engine":         engine,
+			"synced_through": through,
+			"comment_chars":  len(comment),
+		})
+	}
+}
+
+// comment writes the ticket comment for msgs.
+func (w *Worker) comment(ctx context.Context, tt *models.TicketThread, msgs []models.Message, public map[primitive.ObjectID]*models.Channel) (text, engine string, err error) {
+	where := "the meeting board"
+	if ch := public[tt.ChannelID]; ch != nil {
+		where = fmt.Sprintf("#%s on the meeting board", ch.Name)
+	}
+	noun := "messages"
+	if len(msgs) == 1 {
+		noun = "message"
+	}
+
+	summarizer := w.Summarizer
+	if summarizer == nil {
+		summarizer = summary.Extractive{}
+	}
+	res, err := summarizer.Summarize(ctx, summary.Conversation{Title: tt.Ref + " discussion", Messages: msgs})
+	if err != nil {
+		return "", "", err
+	}
+	text = fmt.Sprintf("Discussion in %s (%d new %s, thread %s):\n\n%s", where, len(msgs), noun, tt.RootID.Hex(), res.Text)
+	return text, res.Engine, nil
+}
+
+// backoff returns how long to wait before the given attempt: Interval,
+// doubling with each failure up to maxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	d := w.Interval
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+func (w *Worker) audit(ctx context.Context, action string, tt *models.TicketThread, details map[string]any) {
+	details["ref"] = tt.Ref
+	details["thread_id"] = tt.RootID.Hex()
+	details["channel_id"] = tt.ChannelID.Hex()
+	w.Store.CreateAuditEntry(ctx, &models.AuditEntry{
+		Actor:   Actor,
+		Action:  action,
+		Details: details,
+	})
+}
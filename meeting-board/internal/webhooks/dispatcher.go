@@ -0,0 +1,323 @@
+// Package webhooks delivers board events to subscribed URLs, signing each
+// payload and retrying failed deliveries with exponential backoff.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/store"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Board-Event"
+	HeaderDelivery  = "X-Board-Delivery"
+	HeaderSignature = "X-Board-Signature" // "sha256=" + hex HMAC-SHA256 of the body
+)
+
+const (
+	// DefaultMaxAttempts is how many times a delivery is tried before it is
+	// dead-lettered, when Dispatcher.MaxAttempts is unset.
+	DefaultMaxAttempts = 8
+
+	// DefaultInterval is how often due retries are looked for, when
+	// Dispatcher.Interval is unset.
+	DefaultInterval = 30 * time.Second
+
+	// firstRetry and maxRetry bound the backoff between attempts.
+	firstRetry = 30 * time.Second
+	maxRetry   = time.Hour
+
+	// lease is how long a claimed delivery is hidden from other claims.
+	lease = time.Minute
+
+	// maxConcurrent caps deliveries in flight at once.
+	maxConcurrent = 8
+
+	// publishTimeout bounds recording an event's deliveries.
+	publishTimeout = 5 * time.Second
+)
+
+// Sign returns the signature header value for body under secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Dispatcher turns published events into deliveries for the webhooks that
+// subscribe to them and sends those deliveries. Every delivery is persisted
+// as soon as its event is published and is sent, and retried, from the
+// store, so none are lost to a busy dispatcher or a restart.
+type Dispatcher struct {
+	Store       *store.Store
+	Client      *http.Client // nil means a client with a ten-second timeout
+	Interval    time.Duration
+	MaxAttempts int
+
+	kick  chan struct{}
+	slots chan struct{}
+}
+
+// NewDispatcher creates a Dispatcher; call Run to start it.
+func NewDispatcher(st *store.Store) *Dispatcher {
+	return &Dispatcher{
+		Store: st,
+		kick:  make(chan struct{}, 1),
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+// Publish records a pending delivery of an event for every webhook that
+// subscribes to it, then asks Run to send them. It returns at once; the
+// deliveries are recorded in the background, so a slow store never holds up
+// the request that published the event. fields is the event's JSON object
+// as broadcast to WebSocket subscribers and must not be modified afterwards.
+func (d *Dispatcher) Publish(eventType string, channelID primitive.ObjectID, fields map[string]any) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if d.record(ctx, eventType, channelID, fields) > 0 {
+			d.Retry()
+		}
+	}()
+}
+
+// Retry asks the dispatcher to look for due deliveries now rather than at
+// its next interval.
+func (d *Dispatcher) Retry() {
+	select {
+	case d.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends due deliveries, new ones as they are published and failed ones
+// as their retries come due, until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.retryDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.kick:
+			d.retryDue(ctx)
+		case <-ticker.C:
+			d.retryDue(ctx)
+		}
+	}
+}
+
+// envelope is the body of every delivery.
+type envelope struct {
+	ID        string         `json:"id"`
+	Event     string         `json:"event"`
+	ChannelID string         `json:"channel_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Data      map[string]any `json:"data"`
+}
+
+// record stores a pending delivery, due now, for every webhook matching an
+// event and returns how many it stored.
+func (d *Dispatcher) record(ctx context.Context, eventType string, channelID primitive.ObjectID, fields map[string]any) int {
+	hooks, err := d.Store.MatchWebhooks(ctx, eventType, channelID)
+	if err != nil {
+		log.Printf("webhooks: match %s: %v", eventType, err)
+		return 0
+	}
+	now := time.Now().UTC()
+	var dels []*models.WebhookDelivery
+	for i := range hooks {
+		hook := &hooks[i]
+		if !mentionsMatch(hook.Mentions, fields) {
+			continue
+		}
+
+		del := &models.WebhookDelivery{
+			ID:            primitive.NewObjectID(),
+			WebhookID:     hook.ID,
+			Event:         eventType,
+			ChannelID:     channelID,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		}
+		body, err := json.Marshal(envelope{
+			ID:        del.ID.Hex(),
+			Event:     eventType,
+			ChannelID: channelID.Hex(),
+			CreatedAt: now,
+			Data:      fields,
+		})
+		if err != nil {
+			log.Printf("webhooks: marshal %s: %v", eventType, err)
+			return 0
+		}
+		del.Payload = string(body)
+		dels = append(dels, del)
+	}
+	if len(dels) == 0 {
+		return 0
+	}
+	if err := d.Store.CreateDeliveries(ctx, dels); err != nil {
+		log.Printf("webhooks: record %d %s deliveries: %v", len(dels), eventType, err)
+		return 0
+	}
+	return len(dels)
+}
+
+// retryDue sends every pending delivery that is due, in the background and
+// bounded by maxConcurrent. A slot is taken before a delivery is claimed, so
+// claimed deliveries never wait out their lease.
+func (d *Dispatcher) retryDue(ctx context.Context) {
+	for {
+		select {
+		case d.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		del, err := d.Store.ClaimDueDelivery(ctx, time.Now().UTC(), lease)
+		if err != nil {
+			<-d.slots
+			if err != mongo.ErrNoDocuments {
+				log.Printf("webhooks: claim due delivery: %v", err)
+			}
+			return
+		}
+		hook, err := d.Store.GetWebhook(ctx, del.WebhookID)
+		if err != nil {
+			<-d.slots
+			if err != mongo.ErrNoDocuments {
+				log.Printf("webhooks: get webhook %s: %v", del.WebhookID.Hex(), err)
+				continue
+			}
+			del.Status, del.LastError, del.NextAttemptAt = models.DeliveryDead, "webhook deleted", nil
+			if err := d.Store.SaveDeliveryAttempt(ctx, del); err != nil {
+				log.Printf("webhooks: save delivery %s: %v", del.ID.Hex(), err)
+			}
+			continue
+		}
+		go func() {
+			defer func() { <-d.slots }()
+			d.send(ctx, hook, del)
+		}()
+	}
+}
+
+// send posts del to hook once and records the outcome, scheduling a retry
+// or dead-lettering the delivery if it failed.
+func (d *Dispatcher) send(ctx context.Context, hook *models.Webhook, del *models.WebhookDelivery) {
+	status, err := d.post(ctx, hook, del)
+	del.Attempts++
+	del.LastStatus = status
+	now := time.Now().UTC()
+
+	maxAttempts := d.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	switch {
+	case err == nil:
+		del.Status, del.LastError, del.NextAttemptAt, del.DeliveredAt = models.DeliveryDelivered, "", nil, &now
+	case del.Attempts >= maxAttempts:
+		del.Status, del.LastError, del.NextAttemptAt = models.DeliveryDead, err.Error(), nil
+		log.Printf("webhooks: delivery %s to %s dead after %d attempts: %v", del.ID.Hex(), hook.URL, del.Attempts, err)
+	default:
+		next := now.Add(backoff(del.Attempts))
+		del.LastError, del.NextAttemptAt = err.Error(), &next
+	}
+	if err := d.Store.SaveDeliveryAttempt(ctx, del); err != nil {
+		log.Printf("webhooks: save delivery %s: %v", del.ID.Hex(), err)
+	}
+}
+
+// post sends del's payload to hook, returning the HTTP status (0 if there
+// was none) and an error unless the response was 2xx.
+func (d *Dispatcher) post(ctx context.Context, hook *models.Webhook, del *models.WebhookDelivery) (int, error) {
+	body := []byte(del.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "meeting-board-webhooks")
+	req.Header.Set(HeaderEvent, del.Event)
+	req.Header.Set(HeaderDelivery, del.ID.Hex())
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, body))
+
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait after the given number of failed attempts:
+// firstRetry, doubling each time, up to maxRetry.
+func backoff(attempts int) time.Duration {
+	d := firstRetry
+	for i := 1; i < attempts && d < maxRetry; i++ {
+		d *= 2
+	}
+	return min(d, maxRetry)
+}
+
+// mentionsMatch reports whether an event passes a webhook's mention filter:
+// the filter is empty, or the event carries a message mentioning one of its
+// entries.
+func mentionsMatch(filter []string, fields map[string]any) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	mentions, _ := fields["mentions"].([]any)
+	for _, m := range mentions {
+		name, _ := m.(string)
+		for _, f := range filter {
+			if strings.EqualFold(name, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -25,9 +25,10 @@ type clientAction struct {
 
 // Client represents a single WebSocket connection and its channel subscriptions.
 type Client struct {
-	hub     *Hub
-	conn    *websocket.Conn
-	send    chan []byte
+	hub      *Hub
+	conn     *websocket.Conn
+	send     chan []byte
+	identity string // authenticated author (agent ID, role or "manager")
 	channels map[string]bool
 	mu       sync.Mutex
 }
@@ -50,6 +51,10 @@ type Hub struct {
 	// broadcast receives a channel-scoped message to be sent to subscribers.
 	broadcast chan broadcastMsg
 
+	// Authorize, if set, is consulted before a client subscribes to a channel.
+	// It receives the client's identity and the channel ID.
+	Authorize func(identity, channelID string) bool
+
 	mu sync.RWMutex
 }
 
@@ -193,9 +198,14 @@ func (c *Client) readPump() {
 
 		switch action.Action {
 		case "subscribe":
-			if action.Channel != "" {
-				c.hub.subscribe(c, action.Channel)
+			if action.Channel == "" {
+				continue
+			}
+			if c.hub.Authorize != nil && !c.hub.Authorize(c.identity, action.Channel) {
+				log.Printf("ws: %s not allowed to subscribe to %s", c.identity, action.Channel)
+				continue
 			}
+			c.hub.subscribe(c, action.Channel)
 		case "unsubscribe":
 			if action.Channel != "" {
 				c.hub.unsubscribe(c, action.Channel)
@@ -225,8 +235,9 @@ func (c *Client) writePump() {
 	c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 }
 
-// ServeWs handles WebSocket upgrade requests and registers the new client with the hub.
-func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
+// ServeWs handles WebSocket upgrade requests and registers the new client with
+// the hub under the given identity.
+func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request, identity string) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("ws: upgrade error: %v", err)
@@ -237,6 +248,7 @@ func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
 		hub:      hub,
 		conn:     conn,
 		send:     make(chan []byte, 256),
+		identity: identity,
 		channels: make(map[string]bool),
 	}
 
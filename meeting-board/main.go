@@ -8,12 +8,20 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/devteam/meeting-board/internal/bridge"
+	"github.com/devteam/meeting-board/internal/handlers"
 	"github.com/devteam/meeting-board/internal/models"
+	"github.com/devteam/meeting-board/internal/planning"
+	"github.com/devteam/meeting-board/internal/retention"
 	"github.com/devteam/meeting-board/internal/server"
 	"github.com/devteam/meeting-board/internal/store"
+	"github.com/devteam/meeting-board/internal/summary"
+	"github.com/devteam/meeting-board/internal/ticketsync"
+	"github.com/devteam/meeting-board/internal/webhooks"
 	"github.com/devteam/meeting-board/internal/ws"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -31,6 +39,19 @@ func main() {
 	port := envOrDefault("PORT", "8080")
 	authTokensRaw := envOrDefault("AUTH_TOKENS", "po:token1,dev:token2,cq:token3,qa:token4,ops:token5")
 	agentsRegistryPath := os.Getenv("AGENTS_REGISTRY")
+	clearRetention := envDuration("CLEAR_RETENTION", 7*24*time.Hour)
+	retentionInterval := envDuration("RETENTION_INTERVAL", time.Hour)
+	defaultRetention := models.RetentionPolicy{
+		MaxAgeDays: envInt("RETENTION_MAX_AGE_DAYS", 0),
+		MaxCount:   envInt("RETENTION_MAX_COUNT", 0),
+	}
+	charsPerToken := envInt("CONTEXT_CHARS_PER_TOKEN", 4)
+	summarizerURL := os.Getenv("SUMMARIZER_URL")
+	refPatternsRaw := os.Getenv("REF_PATTERNS")
+	planningBoardURL := os.Getenv("PLANNING_BOARD_URL")
+	ticketThreads := os.Getenv("TICKET_THREADS") == "true"
+	humanCommsType := envOrDefault("HUMAN_COMMS_TYPE", "meeting-board")
+	humanCommsChannel := envOrDefault("HUMAN_COMMS_CHANNEL", "humans")
 
 	tokens := parseAuthTokens(authTokensRaw)
 	log.Printf("Loaded %d auth tokens", len(tokens))
@@ -63,6 +84,23 @@ func main() {
 	db := mongoClient.Database(dbName)
 	st := store.NewStore(db)
 
+	// Ticket and PR reference patterns, separated by ";".
+	if refPatternsRaw != "" {
+		patterns, err := store.CompileRefPatterns(strings.Split(refPatternsRaw, ";"))
+		if err != nil {
+			log.Fatalf("Invalid REF_PATTERNS: %v", err)
+		}
+		st.SetRefPatterns(patterns)
+	}
+	go func() {
+		n, err := st.BackfillRefs(context.Background())
+		if err != nil {
+			log.Printf("Failed to backfill message refs: %v", err)
+		} else if n > 0 {
+			log.Printf("Indexed refs in %d existing messages", n)
+		}
+	}()
+
 	// -----------------------------------------------------------------------
 	// Seed default channels.
 	// -----------------------------------------------------------------------
@@ -74,6 +112,35 @@ func main() {
 	hub := ws.NewHub()
 	go hub.Run()
 
+	// -----------------------------------------------------------------------
+	// Retention worker.
+	// -----------------------------------------------------------------------
+	if defaultRetention.Expires() {
+		log.Printf("Default retention: max age %d days, max count %d", defaultRetention.MaxAgeDays, defaultRetention.MaxCount)
+	}
+	retentionWorker := &retention.Worker{
+		Store:    st,
+		Default:  defaultRetention,
+		Interval: retentionInterval,
+	}
+	go retentionWorker.Run(context.Background())
+
+	// -----------------------------------------------------------------------
+	// Webhook dispatcher.
+	// -----------------------------------------------------------------------
+	dispatcher := webhooks.NewDispatcher(st)
+	dispatcher.Interval = envDuration("WEBHOOK_RETRY_INTERVAL", webhooks.DefaultInterval)
+	dispatcher.MaxAttempts = envInt("WEBHOOK_MAX_ATTEMPTS", webhooks.DefaultMaxAttempts)
+	go dispatcher.Run(context.Background())
+
+	// -----------------------------------------------------------------------
+	// Discord/Slack bridge for #humans.
+	// -----------------------------------------------------------------------
+	var humansBridge *bridge.Bridge
+	if humanCommsType == bridge.KindDiscord || humanCommsType == bridge.KindSlack {
+		humansBridge = startBridge(st, humanCommsType, humanCommsChannel)
+	}
+
 	// -----------------------------------------------------------------------
 	// HTTP server.
 	// -----------------------------------------------------------------------
@@ -82,7 +149,60 @@ func main() {
 		log.Fatalf("Failed to create sub filesystem for web templates: %v", err)
 	}
 
-	router := server.NewServer(st, hub, tokens, agents, webFS)
+	// Summaries use an OpenAI-compatible endpoint when one is configured,
+	// falling back to extractive summaries if it fails.
+	var summarizer summary.Summarizer = summary.Extractive{}
+	if summarizerURL != "" {
+		summarizer = summary.WithFallback(summary.OpenAI{
+			BaseURL: summarizerURL,
+			APIKey:  os.Getenv("SUMMARIZER_API_KEY"),
+			Model:   envOrDefault("SUMMARIZER_MODEL", "gpt-4o-mini"),
+		}, summarizer)
+		log.Printf("Summaries via %s", summarizerURL)
+	}
+
+	// Ticket references are annotated with live ticket status when the
+	// planning board is reachable.
+	var planningBoard planning.Board
+	httpBoard := planning.HTTPBoard{
+		BaseURL: planningBoardURL,
+		Token:   os.Getenv("PLANNING_BOARD_TOKEN"),
+	}
+	if planningBoardURL != "" {
+		planningBoard = &planning.Cache{
+			Board: httpBoard,
+			TTL:   envDuration("PLANNING_CACHE_TTL", time.Minute),
+		}
+		log.Printf("Ticket context from %s", planningBoardURL)
+	}
+
+	// Canonical ticket threads are opt-in. Their discussions are synced to
+	// ticket comments when there is a planning board to post them to.
+	if ticketThreads && planningBoardURL != "" {
+		ticketSync := &ticketsync.Worker{
+			Store:      st,
+			Comments:   httpBoard,
+			Summarizer: summarizer,
+			Interval:   envDuration("TICKET_SYNC_INTERVAL", 5*time.Minute),
+			Quiet:      envDuration("TICKET_SYNC_QUIET", 15*time.Minute),
+		}
+		go ticketSync.Run(context.Background())
+		log.Printf("Ticket threads synced to %s", planningBoardURL)
+	}
+
+	router := server.NewServer(st, hub, server.Config{
+		Tokens:         tokens,
+		Agents:         agents,
+		WebFS:          webFS,
+		ClearRetention: clearRetention,
+		Tokenizer:      handlers.CharTokenizer{CharsPerToken: charsPerToken},
+		Summarizer:     summarizer,
+		PlanningBoard:  planningBoard,
+		TicketThreads:  ticketThreads,
+		Webhooks:       dispatcher,
+		Bridge:         humansBridge,
+		BridgeSecret:   os.Getenv("HUMAN_COMMS_INBOUND_SECRET"),
+	})
 
 	log.Printf("Meeting Board starting on :%s", port)
 	if err := http.ListenAndServe(":"+port, router); err != nil {
@@ -98,6 +218,36 @@ func envOrDefault(key, defaultVal string) string {
 	return defaultVal
 }
 
+// envDuration parses the environment variable as a Go duration (e.g. "168h"),
+// returning the default if it is unset or invalid.
+func envDuration(key string, defaultVal time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("Warning: invalid %s %q, using %s", key, v, defaultVal)
+		return defaultVal
+	}
+	return d
+}
+
+// envInt parses the environment variable as a non-negative integer,
+// returning the default if it is unset or invalid.
+func envInt(key string, defaultVal int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Printf("Warning: invalid %s %q, using %d", key, v, defaultVal)
+		return defaultVal
+	}
+	return n
+}
+
 // parseAuthTokens parses a comma-separated string of "role:token" pairs into a map.
 func parseAuthTokens(raw string) map[string]string {
 	tokens := make(map[string]string)
@@ -118,6 +268,46 @@ func parseAuthTokens(raw string) map[string]string {
 	return tokens
 }
 
+// startBridge mirrors the named channel to the Discord or Slack webhook in
+// HUMAN_COMMS_WEBHOOK_URL. It returns nil, leaving the bridge disabled, if
+// the bridge cannot be set up.
+func startBridge(st *store.Store, kind, channel string) *bridge.Bridge {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ch, err := st.GetChannelByName(ctx, channel)
+	if err != nil {
+		log.Printf("Warning: bridge disabled: channel #%s: %v", channel, err)
+		return nil
+	}
+	b, err := bridge.New(kind, os.Getenv("HUMAN_COMMS_WEBHOOK_URL"), ch.ID)
+	if err != nil {
+		log.Printf("Warning: bridge disabled: %v", err)
+		return nil
+	}
+	b.Store = st
+	b.Mentions = parseBridgeMentions(os.Getenv("HUMAN_COMMS_MENTIONS"))
+	go b.Run(context.Background())
+	log.Printf("Bridging #%s to %s", channel, kind)
+	return b
+}
+
+// parseBridgeMentions parses "handle=mention" pairs, comma-separated, such
+// as "human=<@123456>,manager=<@123456>", into a map keyed by lower-case
+// handle.
+func parseBridgeMentions(raw string) map[string]string {
+	mentions := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		handle, mention, ok := strings.Cut(pair, "=")
+		handle = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+		mention = strings.TrimSpace(mention)
+		if ok && handle != "" && mention != "" {
+			mentions[handle] = mention
+		}
+	}
+	return mentions
+}
+
 // loadAgentsRegistry reads the agents-registry.json file and returns a slice
 // of AgentInfo. Returns nil if the file is not set or cannot be read.
 func loadAgentsRegistry(path string) []models.AgentInfo {